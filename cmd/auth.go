@@ -177,21 +177,31 @@ func workspaceKeyFromAuthResult(userURL, teamID, team string) string {
 var oauthScopes = []string{
 	"channels:history",
 	"channels:read",
+	"chat:write",
+	"emoji:read",
+	"files:read",
+	"files:write",
 	"groups:history",
 	"groups:read",
 	"im:history",
 	"im:read",
+	"im:write",
 	"mpim:history",
 	"mpim:read",
+	"mpim:write",
+	"reactions:read",
+	"reactions:write",
 	"search:read",
+	"usergroups:read",
 	"users:read",
 	"users:read.email",
 }
 
 type AuthCmd struct {
-	Login  AuthLoginCmd  `cmd:"" help:"Authenticate with Slack via OAuth"`
-	Logout AuthLogoutCmd `cmd:"" help:"Remove stored credentials"`
-	Status AuthStatusCmd `cmd:"" help:"Show authentication status"`
+	Login    AuthLoginCmd    `cmd:"" help:"Authenticate with Slack via OAuth"`
+	Logout   AuthLogoutCmd   `cmd:"" help:"Remove stored credentials"`
+	Status   AuthStatusCmd   `cmd:"" help:"Show authentication status"`
+	AppToken AuthAppTokenCmd `cmd:"" help:"Save an app-level token for receiving events with 'listen'"`
 }
 
 func resetAllAuth(cfg *config.Config) {
@@ -396,13 +406,20 @@ func (c *AuthLoginCmd) Run(ctx *Context) error {
 }
 
 func (c *AuthLoginCmd) exchangeCodeForToken(ctx *Context, code, clientID, clientSecret string, replace bool, addNew bool, requestedWorkspace, resolvedWorkspace string, reader *bufio.Reader) error {
-	token, err := slack.ExchangeOAuthCode(clientID, clientSecret, code, oauthRedirectURL)
+	oauthClient, err := ctx.newSlackClient("")
+	if err != nil {
+		return err
+	}
+	token, err := oauthClient.ExchangeOAuthCode(clientID, clientSecret, code, oauthRedirectURL)
 	if err != nil {
 		return fmt.Errorf("failed to exchange code for token: %w", err)
 	}
 
 	// Verify the token works
-	client := slack.NewClient(token)
+	client, err := ctx.newSlackClient(token)
+	if err != nil {
+		return err
+	}
 	user, err := client.AuthTest()
 	if err != nil {
 		return fmt.Errorf("token validation failed: %w", err)
@@ -595,16 +612,28 @@ func workspaceURLForDisplay(workspaceKey string, auth config.WorkspaceAuth, fall
 }
 
 func (c *AuthStatusCmd) Run(ctx *Context) error {
+	format := ctx.format()
 	requestedWorkspace := strings.TrimSpace(ctx.Workspace)
 	token, resolvedWorkspace, err := ctx.Config.TokenForWorkspace(requestedWorkspace)
 	if err != nil {
+		if format.Structured() {
+			doc := authStatusJSON{Error: err.Error()}
+			return writeStructured(format, doc, []authStatusJSON{doc})
+		}
 		fmt.Println("Not logged in. Run 'slack-cli auth login' to authenticate.")
 		return nil
 	}
 
-	client := slack.NewClient(token)
+	client, err := ctx.newSlackClient(token)
+	if err != nil {
+		return err
+	}
 	user, err := client.AuthTest()
 	if err != nil {
+		if format.Structured() {
+			doc := authStatusJSON{Workspace: resolvedWorkspace, Error: err.Error()}
+			return writeStructured(format, doc, []authStatusJSON{doc})
+		}
 		fmt.Printf("Token invalid: %v\n", err)
 		return nil
 	}
@@ -614,29 +643,84 @@ func (c *AuthStatusCmd) Run(ctx *Context) error {
 		resolvedDisplay = workspaceURLForDisplay(resolvedWorkspace, auth, user.URL)
 	}
 
-	fmt.Printf("Logged in as %s in workspace %s (%s)\n", user.User, user.Team, resolvedDisplay)
+	keys := make([]string, 0, len(ctx.Config.Workspaces))
+	for k := range ctx.Config.Workspaces {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
 
-	if len(ctx.Config.Workspaces) > 1 {
-		keys := make([]string, 0, len(ctx.Config.Workspaces))
-		for k := range ctx.Config.Workspaces {
-			keys = append(keys, k)
+	workspaces := make([]workspaceJSON, 0, len(keys))
+	for _, key := range keys {
+		auth := ctx.Config.Workspaces[key]
+		fallbackURL := ""
+		if key == resolvedWorkspace {
+			fallbackURL = user.URL
+		}
+		workspaces = append(workspaces, workspaceJSON{
+			Workspace: key,
+			URL:       workspaceURLForDisplay(key, auth, fallbackURL),
+			Default:   key == ctx.Config.CurrentWorkspace,
+		})
+	}
+
+	if format.Structured() {
+		doc := authStatusJSON{
+			LoggedIn:   true,
+			Workspace:  resolvedWorkspace,
+			URL:        user.URL,
+			Team:       user.Team,
+			TeamID:     user.TeamID,
+			User:       user.User,
+			UserID:     user.UserID,
+			Workspaces: workspaces,
 		}
-		sort.Strings(keys)
+		return writeStructured(format, doc, []authStatusJSON{doc})
+	}
+
+	fmt.Printf("Logged in as %s in workspace %s (%s)\n", user.User, user.Team, resolvedDisplay)
+
+	if len(workspaces) > 1 {
 		fmt.Println("Configured workspaces:")
-		for _, key := range keys {
-			auth := ctx.Config.Workspaces[key]
-			fallbackURL := ""
-			if key == resolvedWorkspace {
-				fallbackURL = user.URL
-			}
-			display := workspaceURLForDisplay(key, auth, fallbackURL)
+		for _, ws := range workspaces {
 			current := ""
-			if key == ctx.Config.CurrentWorkspace {
+			if ws.Default {
 				current = " (default)"
 			}
-			fmt.Printf("- %s%s\n", display, current)
+			fmt.Printf("- %s%s\n", ws.URL, current)
 		}
 	}
 
 	return nil
 }
+
+type AuthAppTokenCmd struct {
+	Token string `arg:"" optional:"" help:"App-level token (xapp-...) with the connections:write scope; prompted for if omitted"`
+}
+
+func (c *AuthAppTokenCmd) Run(ctx *Context) error {
+	workspace, err := ctx.Config.ResolveWorkspace(ctx.Workspace)
+	if err != nil {
+		return fmt.Errorf("%w. Run 'slack-cli auth login' first", err)
+	}
+
+	token := strings.TrimSpace(c.Token)
+	if token == "" {
+		fmt.Print("App-level token: ")
+		input, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("failed to read app-level token: %w", err)
+		}
+		token = strings.TrimSpace(input)
+	}
+	if !strings.HasPrefix(token, "xapp-") {
+		return fmt.Errorf("expected an app-level token starting with xapp-; create one under Basic Information > App-Level Tokens in your Slack app's settings")
+	}
+
+	ctx.Config.SetWorkspaceAppToken(workspace, token)
+	if err := ctx.Config.Save(); err != nil {
+		return fmt.Errorf("failed to save app-level token: %w", err)
+	}
+
+	fmt.Printf("Saved app-level token for %s\n", workspace)
+	return nil
+}
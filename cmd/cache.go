@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lox/slack-cli/internal/cache"
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+type CacheCmd struct {
+	Warm  CacheWarmCmd  `cmd:"" help:"Load every user and channel into the cache"`
+	Clear CacheClearCmd `cmd:"" help:"Remove cached users and channels"`
+	Stats CacheStatsCmd `cmd:"" help:"Show what is cached for each workspace"`
+}
+
+type CacheWarmCmd struct{}
+
+func (c *CacheWarmCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+
+	root, err := ctx.cacheRoot()
+	if err != nil {
+		return err
+	}
+
+	users, channels, err := slack.WarmCache(client, root.Sub(ctx.workspaceKey))
+	if err != nil {
+		return fmt.Errorf("failed to warm cache: %w", err)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := cacheWarmJSON{Workspace: ctx.workspaceKey, Users: users, Channels: channels}
+		return writeStructured(format, doc, []cacheWarmJSON{doc})
+	}
+
+	fmt.Printf("Cached %d users and %d channels for %s\n", users, channels, ctx.workspaceKey)
+	return nil
+}
+
+type CacheClearCmd struct {
+	All bool `help:"Clear the cache for every workspace"`
+}
+
+func (c *CacheClearCmd) Run(ctx *Context) error {
+	root, err := ctx.cacheRoot()
+	if err != nil {
+		return err
+	}
+
+	if c.All {
+		if err := root.Clear(); err != nil {
+			return fmt.Errorf("failed to clear cache: %w", err)
+		}
+		fmt.Fprintln(os.Stderr, "Cleared the cache for every workspace")
+		return nil
+	}
+
+	_, workspace, err := ctx.resolveToken("")
+	if err != nil {
+		return err
+	}
+	if err := root.Sub(workspace).Clear(); err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Cleared the cache for %s\n", workspace)
+	return nil
+}
+
+type CacheStatsCmd struct{}
+
+func (c *CacheStatsCmd) Run(ctx *Context) error {
+	root, err := ctx.cacheRoot()
+	if err != nil {
+		return err
+	}
+
+	workspaces, err := root.Subs()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	doc := cacheStatsJSON{Workspaces: make([]cacheWorkspaceJSON, 0, len(workspaces))}
+	stats := make(map[string][]cache.Stat, len(workspaces))
+	for _, workspace := range workspaces {
+		entries, err := root.Sub(workspace).Stats()
+		if err != nil {
+			return fmt.Errorf("failed to read cache for %s: %w", workspace, err)
+		}
+		stats[workspace] = entries
+
+		ws := cacheWorkspaceJSON{Workspace: workspace, Entries: make([]cacheEntryJSON, 0, len(entries))}
+		for _, stat := range entries {
+			ws.Entries = append(ws.Entries, cacheEntryJSON{
+				Name:    stat.Key,
+				Items:   stat.Items,
+				Size:    stat.Size,
+				SavedAt: stat.SavedAt.UTC().Format(time.RFC3339),
+			})
+		}
+		doc.Workspaces = append(doc.Workspaces, ws)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		return writeStructured(format, doc, doc.Workspaces)
+	}
+
+	if len(workspaces) == 0 {
+		fmt.Println("Cache is empty")
+		return nil
+	}
+	for _, workspace := range workspaces {
+		fmt.Println(workspace)
+		for _, stat := range stats[workspace] {
+			age := time.Since(stat.SavedAt).Round(time.Minute)
+			fmt.Printf("  %s: %d entries, %s, updated %s ago\n", stat.Key, stat.Items, formatSize(stat.Size), age)
+		}
+	}
+	return nil
+}
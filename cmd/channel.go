@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/lox/slack-cli/internal/output"
 	"github.com/lox/slack-cli/internal/slack"
 )
 
@@ -14,7 +15,8 @@ type ChannelCmd struct {
 }
 
 type ChannelListCmd struct {
-	Limit int `help:"Maximum number of channels to list" default:"100"`
+	Limit int    `help:"Maximum number of channels to list (0 for all)" default:"100"`
+	Types string `help:"Comma-separated conversation types: public, private, im, mpim" default:"public,private"`
 }
 
 func (c *ChannelListCmd) Run(ctx *Context) error {
@@ -22,63 +24,167 @@ func (c *ChannelListCmd) Run(ctx *Context) error {
 	if err != nil {
 		return err
 	}
-	resp, err := client.ListConversations("public_channel,private_channel", c.Limit)
+	types, err := conversationTypes(c.Types)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListConversations(types, c.Limit)
 	if err != nil {
 		return fmt.Errorf("failed to list channels: %w", err)
 	}
 
+	if format := ctx.format(); format.Structured() {
+		doc := channelListJSON{
+			Channels:   make([]channelJSON, 0, len(resp.Channels)),
+			HasMore:    resp.HasMore,
+			NextCursor: resp.ResponseMetadata.NextCursor,
+		}
+		for _, ch := range resp.Channels {
+			doc.Channels = append(doc.Channels, newChannelJSON(ch))
+		}
+		return writeStructured(format, doc, doc.Channels)
+	}
+
+	resolver := ctx.newResolver(client)
 	for _, ch := range resp.Channels {
-		prefix := "#"
-		if ch.IsPrivate {
-			prefix = "🔒"
+		if ch.IsIM || ch.IsMPIM {
+			fmt.Printf("%s (%s)\n", conversationLabel(resolver, ch), ch.ID)
+			continue
 		}
-		fmt.Printf("%s%s (%d members) - %s\n", prefix, ch.Name, ch.NumMembers, ch.Purpose.Value)
+		fmt.Printf("%s (%d members) - %s\n", conversationLabel(resolver, ch), ch.NumMembers, ch.Purpose.Value)
+	}
+
+	if resp.HasMore {
+		printMoreHint("channels")
 	}
 
 	return nil
 }
 
+// conversationTypes expands the --types shorthand into Slack's
+// conversation type names.
+func conversationTypes(types string) (string, error) {
+	names := map[string]string{
+		"public":  "public_channel",
+		"private": "private_channel",
+		"im":      "im",
+		"mpim":    "mpim",
+	}
+
+	var out []string
+	for _, t := range strings.Split(types, ",") {
+		t = strings.TrimSpace(t)
+		name, ok := names[t]
+		if !ok {
+			for _, v := range names {
+				if v == t {
+					name, ok = v, true
+				}
+			}
+		}
+		if !ok {
+			return "", fmt.Errorf("unknown conversation type %q; use public, private, im or mpim", t)
+		}
+		out = append(out, name)
+	}
+	return strings.Join(out, ","), nil
+}
+
+// conversationLabel names a conversation for display: #name for channels,
+// 🔒name for private ones, and the participants for DMs.
+func conversationLabel(resolver *slack.Resolver, ch slack.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "@" + resolver.ResolveUser(ch.User)
+	case ch.IsMPIM:
+		return strings.Join(mpimMembers(ch.Name), ", ")
+	case ch.IsPrivate:
+		return "🔒" + ch.Name
+	}
+	return "#" + ch.Name
+}
+
+// mpimMembers extracts the usernames from a group DM's generated name, such as
+// "mpdm-alice--bob--carol-1".
+func mpimMembers(name string) []string {
+	name = strings.TrimPrefix(name, "mpdm-")
+	if i := strings.LastIndex(name, "-"); i > 0 && !strings.HasSuffix(name[:i], "-") {
+		name = name[:i]
+	}
+	return strings.Split(name, "--")
+}
+
 type ChannelReadCmd struct {
-	Channel string `arg:"" help:"Channel name or ID"`
-	Limit   int    `help:"Number of messages to show" default:"20"`
+	Channel   string         `arg:"" help:"Channel name or ID"`
+	Limit     int            `help:"Number of messages to show (0 for all)" default:"20"`
+	Reactors  bool           `help:"Show who reacted to each message"`
+	Follow    bool           `help:"Keep watching for new and edited messages until interrupted (JSON output becomes NDJSON)" short:"f"`
+	TimeRange TimeRangeFlags `embed:""`
 }
 
 func (c *ChannelReadCmd) Run(ctx *Context) error {
-	client, err := ctx.NewClient("")
+	opts, err := c.TimeRange.historyOptions(c.Limit)
 	if err != nil {
 		return err
 	}
-	resolver := slack.NewResolver(client)
+	if c.Follow && c.TimeRange.Until != "" {
+		return fmt.Errorf("--follow can't be combined with --until")
+	}
 
-	// Resolve channel name to ID if needed
-	channelID := c.Channel
-	if strings.HasPrefix(c.Channel, "#") {
-		channelID = strings.TrimPrefix(c.Channel, "#")
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
 	}
-	if !strings.HasPrefix(channelID, "C") && !strings.HasPrefix(channelID, "G") {
-		// Try to find by name
-		resp, err := client.ListConversations("public_channel,private_channel", 1000)
-		if err != nil {
-			return fmt.Errorf("failed to list channels: %w", err)
-		}
-		for _, ch := range resp.Channels {
-			if ch.Name == channelID {
-				channelID = ch.ID
-				break
-			}
-		}
+	resolver := ctx.newResolver(client)
+
+	channelID, err := ctx.resolveChannelID(client, c.Channel)
+	if err != nil {
+		return err
 	}
 
-	history, err := client.GetConversationHistory(channelID, c.Limit)
+	return readHistory(ctx, client, resolver, channelID, opts, c.Reactors, c.Follow)
+}
+
+// readHistory prints a conversation's history, oldest first, in the selected
+// output format, then with following set keeps printing new messages.
+func readHistory(ctx *Context, client *slack.Client, resolver *slack.Resolver, channelID string, opts slack.HistoryOptions, reactors, following bool) error {
+	history, err := client.GetConversationHistory(channelID, opts)
 	if err != nil {
 		return fmt.Errorf("failed to get channel history: %w", err)
 	}
 
-	// Print messages in reverse order (oldest first)
-	for i := len(history.Messages) - 1; i >= 0; i-- {
-		msg := history.Messages[i]
-		user := resolver.ResolveUser(msg.User)
-		fmt.Printf("[%s] %s: %s\n", msg.TS, user, resolver.FormatText(msg.Text))
+	// Print messages oldest first
+	messages := oldestFirst(history.Messages)
+	if following {
+		return follow(ctx, client, resolver, channelID, "", messages, reactors)
+	}
+	resolver.Prefetch(messages)
+
+	switch format := ctx.format(); {
+	case format.Structured():
+		info, err := client.GetConversationInfo(channelID)
+		if err != nil {
+			return fmt.Errorf("failed to get channel info: %w", err)
+		}
+		doc := messagesJSON{
+			Channel:    newChannelJSON(*info),
+			Messages:   newMessagesJSON(resolver, channelID, messages),
+			HasMore:    history.HasMore,
+			NextCursor: history.ResponseMetadata.NextCursor,
+		}
+		return writeStructured(format, doc, doc.Messages)
+	case format == output.FormatMarkdown:
+		var sb strings.Builder
+		formatter := &messageFormatter{resolver: resolver, reactors: reactors}
+		formatter.writeChannel(&sb, messages)
+		fmt.Print(sb.String())
+	default:
+		printMessages(resolver, messages, reactors)
+	}
+
+	if history.HasMore {
+		printMoreHint("messages")
 	}
 
 	return nil
@@ -94,13 +200,21 @@ func (c *ChannelInfoCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	channelID := strings.TrimPrefix(c.Channel, "#")
+	channelID, err := ctx.resolveChannelID(client, c.Channel)
+	if err != nil {
+		return err
+	}
 
 	info, err := client.GetConversationInfo(channelID)
 	if err != nil {
 		return fmt.Errorf("failed to get channel info: %w", err)
 	}
 
+	if format := ctx.format(); format.Structured() {
+		doc := newChannelJSON(*info)
+		return writeStructured(format, doc, []channelJSON{doc})
+	}
+
 	fmt.Printf("Name: #%s\n", info.Name)
 	fmt.Printf("ID: %s\n", info.ID)
 	fmt.Printf("Members: %d\n", info.NumMembers)
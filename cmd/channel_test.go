@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConversationTypes(t *testing.T) {
+	tests := map[string]string{
+		"public,private":     "public_channel,private_channel",
+		"im, mpim":           "im,mpim",
+		"public_channel,im":  "public_channel,im",
+		"private_channel":    "private_channel",
+		"public,private,im":  "public_channel,private_channel,im",
+		"mpim,public":        "mpim,public_channel",
+		"private, public":    "private_channel,public_channel",
+		"im,private_channel": "im,private_channel",
+	}
+	for input, want := range tests {
+		got, err := conversationTypes(input)
+		if err != nil {
+			t.Fatalf("conversationTypes(%q) returned error: %v", input, err)
+		}
+		if got != want {
+			t.Fatalf("conversationTypes(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if _, err := conversationTypes("public,dm"); err == nil {
+		t.Fatal("expected an error for an unknown type")
+	}
+}
+
+func TestMpimMembers(t *testing.T) {
+	tests := map[string][]string{
+		"mpdm-alice--bob--carol-1":   {"alice", "bob", "carol"},
+		"mpdm-mary-jane--bob-1":      {"mary-jane", "bob"},
+		"mpdm-alice--bob.smith--c-2": {"alice", "bob.smith", "c"},
+	}
+	for name, want := range tests {
+		if got := mpimMembers(name); !reflect.DeepEqual(got, want) {
+			t.Fatalf("mpimMembers(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+var dmIDPattern = regexp.MustCompile(`^D[A-Z0-9]{6,}$`)
+
+type DmCmd struct {
+	List DmListCmd `cmd:"" help:"List direct messages and group DMs"`
+	Read DmReadCmd `cmd:"" help:"Read direct messages with one or more people"`
+}
+
+type DmListCmd struct {
+	Limit int `help:"Maximum number of conversations to list (0 for all)" default:"100"`
+}
+
+func (c *DmListCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.ListConversations("im,mpim", c.Limit)
+	if err != nil {
+		return fmt.Errorf("failed to list direct messages: %w", err)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := channelListJSON{
+			Channels:   make([]channelJSON, 0, len(resp.Channels)),
+			HasMore:    resp.HasMore,
+			NextCursor: resp.ResponseMetadata.NextCursor,
+		}
+		for _, ch := range resp.Channels {
+			doc.Channels = append(doc.Channels, newChannelJSON(ch))
+		}
+		return writeStructured(format, doc, doc.Channels)
+	}
+
+	resolver := ctx.newResolver(client)
+	for _, ch := range resp.Channels {
+		fmt.Printf("%s (%s)\n", conversationLabel(resolver, ch), ch.ID)
+	}
+
+	if resp.HasMore {
+		printMoreHint("conversations")
+	}
+
+	return nil
+}
+
+type DmReadCmd struct {
+	Users     []string       `arg:"" help:"People to read DMs with, by @username, email or user ID, or a DM conversation ID (D...)"`
+	Limit     int            `help:"Number of messages to show (0 for all)" default:"20"`
+	Reactors  bool           `help:"Show who reacted to each message"`
+	Follow    bool           `help:"Keep watching for new and edited messages until interrupted (JSON output becomes NDJSON)" short:"f"`
+	TimeRange TimeRangeFlags `embed:""`
+}
+
+func (c *DmReadCmd) Run(ctx *Context) error {
+	opts, err := c.TimeRange.historyOptions(c.Limit)
+	if err != nil {
+		return err
+	}
+	if c.Follow && c.TimeRange.Until != "" {
+		return fmt.Errorf("--follow can't be combined with --until")
+	}
+
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+	resolver := ctx.newResolver(client)
+
+	channelID, err := c.conversationID(client, resolver)
+	if err != nil {
+		return err
+	}
+
+	return readHistory(ctx, client, resolver, channelID, opts, c.Reactors, c.Follow)
+}
+
+// conversationID returns the DM with a single person, or the group DM with
+// several, opening it if needed.
+func (c *DmReadCmd) conversationID(client *slack.Client, resolver *slack.Resolver) (string, error) {
+	if len(c.Users) == 1 && dmIDPattern.MatchString(c.Users[0]) {
+		return c.Users[0], nil
+	}
+
+	userIDs := make([]string, 0, len(c.Users))
+	for _, ref := range c.Users {
+		user, err := lookupUser(client, resolver, ref)
+		if err != nil {
+			return "", fmt.Errorf("failed to find user %s: %w", ref, err)
+		}
+		userIDs = append(userIDs, user.ID)
+	}
+
+	ch, err := client.OpenConversation(userIDs)
+	if err != nil {
+		return "", fmt.Errorf("failed to open conversation: %w", err)
+	}
+	return ch.ID, nil
+}
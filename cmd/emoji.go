@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+type EmojiCmd struct {
+	List EmojiListCmd `cmd:"" help:"List the workspace's custom emoji"`
+}
+
+type EmojiListCmd struct {
+	Search string `help:"Only show emoji whose names contain this text"`
+}
+
+func (c *EmojiListCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+
+	custom, err := client.ListEmoji()
+	if err != nil {
+		return fmt.Errorf("failed to list emoji: %w", err)
+	}
+
+	search := strings.ToLower(strings.Trim(c.Search, ":"))
+	var emoji []emojiJSON
+	for _, e := range slack.CustomEmojiList(custom) {
+		if search != "" && !strings.Contains(e.Name, search) {
+			continue
+		}
+		emoji = append(emoji, emojiJSON{Name: e.Name, URL: e.URL, AliasFor: e.AliasFor})
+	}
+	sort.Slice(emoji, func(i, j int) bool { return emoji[i].Name < emoji[j].Name })
+
+	if format := ctx.format(); format.Structured() {
+		doc := emojiListJSON{Emoji: emoji}
+		if doc.Emoji == nil {
+			doc.Emoji = []emojiJSON{}
+		}
+		return writeStructured(format, doc, doc.Emoji)
+	}
+
+	if len(emoji) == 0 {
+		fmt.Println("No custom emoji found.")
+		return nil
+	}
+	for _, e := range emoji {
+		if e.AliasFor != "" {
+			fmt.Printf(":%s: (alias for :%s:)\n", e.Name, e.AliasFor)
+			continue
+		}
+		fmt.Printf(":%s: %s\n", e.Name, e.URL)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+// Process exit codes, so scripts can tell failure modes apart.
+const (
+	ExitError        = 1
+	ExitAuth         = 3
+	ExitMissingScope = 4
+	ExitNotFound     = 5
+	ExitRateLimited  = 6
+	ExitNotAuthor    = 7
+)
+
+// NotAuthorError is returned when asked to edit or delete a message that the
+// authenticated user didn't write.
+type NotAuthorError struct {
+	Channel string
+	TS      string
+	// Author is the user ID of the message's author, if it has one.
+	Author string
+	// User is the user ID of the authenticated user.
+	User string
+}
+
+func (e *NotAuthorError) Error() string {
+	if e.Author == "" {
+		return fmt.Sprintf("message %s in %s was not posted by you (%s)", e.TS, e.Channel, e.User)
+	}
+	return fmt.Sprintf("message %s in %s was posted by %s, not you (%s)", e.TS, e.Channel, e.Author, e.User)
+}
+
+// ExitCode maps an error returned by a command to a process exit code.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, new(*NotAuthorError)):
+		return ExitNotAuthor
+	case slack.IsTokenRevoked(err):
+		return ExitAuth
+	case slack.IsMissingScope(err):
+		return ExitMissingScope
+	case slack.IsNotFound(err):
+		return ExitNotFound
+	case slack.IsRateLimited(err):
+		return ExitRateLimited
+	default:
+		return ExitError
+	}
+}
+
+// DescribeError adds a remediation hint to Slack errors the user can fix.
+func DescribeError(err error) error {
+	var apiErr *slack.APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	switch {
+	case slack.IsTokenRevoked(err):
+		return fmt.Errorf("%w. Run 'slack-cli auth login' to re-authenticate", err)
+	case slack.IsMissingScope(err):
+		return fmt.Errorf("%w. Update your Slack app with the scopes in slack-app-manifest.yaml and run 'slack-cli auth login --replace'", err)
+	case slack.IsRateLimited(err):
+		return fmt.Errorf("%w. Slack is rate limiting %s; try again shortly", err, apiErr.Method)
+	}
+	return err
+}
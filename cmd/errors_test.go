@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "plain error", err: errors.New("boom"), want: ExitError},
+		{name: "revoked token", err: &slack.APIError{Code: "token_revoked"}, want: ExitAuth},
+		{name: "invalid auth", err: &slack.APIError{Code: "invalid_auth"}, want: ExitAuth},
+		{name: "missing scope", err: &slack.APIError{Code: "missing_scope", Needed: "chat:write"}, want: ExitMissingScope},
+		{name: "wrapped not found", err: fmt.Errorf("failed to get thread: %w", &slack.APIError{Code: "thread_not_found"}), want: ExitNotFound},
+		{name: "unknown channel name", err: &slack.ChannelNotFoundError{Name: "genral"}, want: ExitNotFound},
+		{name: "HTTP 429", err: &slack.APIError{StatusCode: http.StatusTooManyRequests}, want: ExitRateLimited},
+		{name: "other API error", err: &slack.APIError{Code: "not_in_channel"}, want: ExitError},
+		{name: "not author", err: &NotAuthorError{Channel: "C1", TS: "1.2", Author: "U2", User: "U1"}, want: ExitNotAuthor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Fatalf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribeErrorAddsHints(t *testing.T) {
+	err := DescribeError(fmt.Errorf("failed: %w", &slack.APIError{Code: "missing_scope", Needed: "chat:write", Provided: "search:read"}))
+	if !strings.Contains(err.Error(), "needed: chat:write") {
+		t.Fatalf("expected needed scope in message, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "auth login --replace") {
+		t.Fatalf("expected re-auth hint, got %q", err.Error())
+	}
+	if !slack.IsMissingScope(err) {
+		t.Fatalf("expected described error to still unwrap to the API error")
+	}
+
+	plain := errors.New("boom")
+	if got := DescribeError(plain); got != plain {
+		t.Fatalf("expected non-Slack errors to be returned unchanged, got %v", got)
+	}
+}
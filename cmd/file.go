@@ -0,0 +1,402 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lox/slack-cli/internal/slack"
+	"golang.org/x/term"
+)
+
+var fileIDPattern = regexp.MustCompile(`^F[A-Z0-9]{6,}$`)
+
+type FileCmd struct {
+	Download FileDownloadCmd `cmd:"" help:"Download files by file ID, file URL or message URL"`
+	Upload   FileUploadCmd   `cmd:"" help:"Upload files to a channel or thread"`
+}
+
+type FileDownloadCmd struct {
+	File string `arg:"" help:"File ID (F123...), file URL, or a message URL to download all of its files"`
+	Dir  string `help:"Directory to save files in; files already downloaded there (recorded by ID in .slack-cli-downloads.json) are skipped" default:"." type:"path"`
+}
+
+func (c *FileDownloadCmd) Run(ctx *Context) error {
+	urlHint := ""
+	if strings.Contains(c.File, "://") {
+		urlHint = c.File
+	}
+
+	client, err := ctx.NewClient(urlHint)
+	if err != nil {
+		return err
+	}
+
+	files, err := c.files(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	downloads, err := downloadFiles(client, files, c.Dir)
+	if format := ctx.format(); format.Structured() && downloads != nil {
+		doc := fileDownloadsJSON{Files: downloads}
+		if writeErr := writeStructured(format, doc, doc.Files); writeErr != nil {
+			return writeErr
+		}
+	}
+	return err
+}
+
+type FileUploadCmd struct {
+	Paths    []string `arg:"" help:"Files to upload, or - to read one from stdin"`
+	Channel  string   `help:"Channel name or ID to share the files in" short:"c"`
+	Thread   string   `help:"Thread URL to share the files in as a reply"`
+	Comment  string   `help:"Message to post with the files"`
+	Title    string   `help:"Title for the file (defaults to its name)"`
+	Name     string   `help:"File name for stdin uploads" default:"stdin.txt"`
+	Snippet  bool     `help:"Upload text as a snippet"`
+	Filetype string   `help:"Snippet syntax type, e.g. go, python or shell (implies --snippet)"`
+}
+
+func (c *FileUploadCmd) Run(ctx *Context) error {
+	if c.Channel == "" && c.Thread == "" {
+		return fmt.Errorf("provide --channel or --thread")
+	}
+	if c.Title != "" && len(c.Paths) > 1 {
+		return fmt.Errorf("--title can only be used when uploading a single file")
+	}
+
+	uploads, cleanup, err := c.uploads(os.Stdin)
+	defer cleanup()
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.NewClient(c.Thread)
+	if err != nil {
+		return err
+	}
+
+	opts := slack.UploadOptions{InitialComment: c.Comment}
+	if c.Thread != "" {
+		opts.ChannelID, opts.ThreadTS, err = parseThreadParent(c.Thread)
+		if err != nil {
+			return err
+		}
+	} else {
+		opts.ChannelID, err = ctx.resolveChannelID(client, c.Channel)
+		if err != nil {
+			return err
+		}
+	}
+
+	files, err := client.UploadFiles(uploads, opts)
+	if err != nil {
+		return fmt.Errorf("failed to upload files: %w", ctx.augmentChannelNotFoundError(c.Thread, err))
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := uploadedFilesJSON{Channel: opts.ChannelID, ThreadTS: opts.ThreadTS, Files: make([]fileJSON, 0, len(files))}
+		for _, f := range files {
+			doc.Files = append(doc.Files, newFileJSON(f))
+		}
+		return writeStructured(format, doc, doc.Files)
+	}
+
+	for _, f := range files {
+		fmt.Println(f.Permalink)
+	}
+	return nil
+}
+
+// uploads opens the files to upload. The returned cleanup closes them and
+// must be called even on error.
+func (c *FileUploadCmd) uploads(stdin io.Reader) ([]slack.FileUpload, func(), error) {
+	var opened []*os.File
+	cleanup := func() {
+		for _, f := range opened {
+			f.Close() //nolint:errcheck
+		}
+	}
+
+	snippetType := c.Filetype
+	if c.Snippet && snippetType == "" {
+		snippetType = "text"
+	}
+
+	var uploads []slack.FileUpload
+	for _, path := range c.Paths {
+		upload := slack.FileUpload{Title: c.Title, SnippetType: snippetType}
+
+		if path == "-" {
+			data, err := io.ReadAll(stdin)
+			if err != nil {
+				return nil, cleanup, fmt.Errorf("failed to read stdin: %w", err)
+			}
+			upload.Name, upload.Content, upload.Size = c.Name, bytes.NewReader(data), int64(len(data))
+		} else {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, cleanup, err
+			}
+			opened = append(opened, f)
+			stat, err := f.Stat()
+			if err != nil {
+				return nil, cleanup, err
+			}
+			if !stat.Mode().IsRegular() {
+				return nil, cleanup, fmt.Errorf("%s is not a regular file", path)
+			}
+			upload.Name, upload.Content, upload.Size = filepath.Base(path), f, stat.Size()
+		}
+
+		if upload.Size == 0 {
+			return nil, cleanup, fmt.Errorf("%s is empty", upload.Name)
+		}
+		if snippetType != "" {
+			if err := checkSnippet(&upload); err != nil {
+				return nil, cleanup, err
+			}
+		}
+		uploads = append(uploads, upload)
+	}
+
+	if len(uploads) == 0 {
+		return nil, cleanup, fmt.Errorf("no files to upload")
+	}
+	return uploads, cleanup, nil
+}
+
+// checkSnippet rejects binary content for snippets, which must be text.
+func checkSnippet(upload *slack.FileUpload) error {
+	data, err := io.ReadAll(upload.Content)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", upload.Name, err)
+	}
+	if !utf8.Valid(data) {
+		return fmt.Errorf("%s is not text, so it can't be uploaded as a snippet", upload.Name)
+	}
+	upload.Content = bytes.NewReader(data)
+	return nil
+}
+
+// files returns the files to download: a single file for a file ID or URL, or
+// every file attached to the message a permalink points to.
+func (c *FileDownloadCmd) files(ctx *Context, client *slack.Client) ([]slack.File, error) {
+	if fileID := fileIDFromRef(c.File); fileID != "" {
+		file, err := client.GetFileInfo(fileID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get file info: %w", err)
+		}
+		return []slack.File{*file}, nil
+	}
+
+	info, err := parseSlackURL(c.File)
+	if err != nil || info.MessageTS == "" {
+		return nil, fmt.Errorf("expected a file ID, file URL or message URL, got %q", c.File)
+	}
+
+	msg, err := client.GetMessage(info.Channel, info.MessageTS, info.ThreadTS)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message: %w", ctx.augmentChannelNotFoundError(c.File, err))
+	}
+	if len(msg.Files) == 0 {
+		return nil, fmt.Errorf("message has no files")
+	}
+	return msg.Files, nil
+}
+
+// fileIDFromRef extracts a file ID from a bare ID, a file permalink
+// (/files/U123/F123/name) or a private file URL (/files-pri/T123-F123/name).
+func fileIDFromRef(ref string) string {
+	if fileIDPattern.MatchString(ref) {
+		return ref
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	for _, segment := range strings.Split(u.Path, "/") {
+		for _, part := range strings.Split(segment, "-") {
+			if fileIDPattern.MatchString(part) {
+				return part
+			}
+		}
+	}
+	return ""
+}
+
+// downloadFiles saves files into dir, skipping any already downloaded there.
+// Files are written to a temporary name and renamed into place so that
+// interrupted downloads don't look complete. Progress goes to stderr. A file
+// that fails is reported in its result and the rest are still downloaded;
+// the returned error joins every failure.
+func downloadFiles(client *slack.Client, files []slack.File, dir string) ([]fileDownloadJSON, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create download directory: %w", err)
+	}
+	record := loadDownloadRecord(dir)
+
+	downloads := make([]fileDownloadJSON, 0, len(files))
+	var errs []error
+	failed := func(file slack.File, err error) {
+		downloads = append(downloads, fileDownloadJSON{ID: file.ID, Name: fileName(file), Error: err.Error()})
+		errs = append(errs, err)
+	}
+	for _, file := range files {
+		if file.URLPrivateDownload == "" && file.URLPrivate == "" {
+			full, err := client.GetFileInfo(file.ID)
+			if err != nil {
+				failed(file, fmt.Errorf("failed to get file info for %s: %w", file.ID, err))
+				continue
+			}
+			file = *full
+		}
+
+		path, existing := downloadPath(dir, file, record)
+		result := fileDownloadJSON{ID: file.ID, Name: fileName(file), Path: path, Size: int64(file.Size)}
+		if existing {
+			result.Skipped = true
+			downloads = append(downloads, result)
+			fmt.Fprintf(os.Stderr, "Skipped %s (%s already downloaded)\n", path, file.ID)
+			continue
+		}
+
+		size, err := downloadFile(client, file, path)
+		if err != nil {
+			failed(file, fmt.Errorf("failed to download %s: %w", fileName(file), err))
+			continue
+		}
+		record[filepath.Base(path)] = file.ID
+		record.save(dir)
+		result.Size = size
+		downloads = append(downloads, result)
+		fmt.Fprintf(os.Stderr, "Downloaded %s (%s)\n", path, formatSize(size))
+	}
+
+	return downloads, errors.Join(errs...)
+}
+
+// downloadRecordName is the file in a download directory that records which
+// Slack file each download there came from.
+const downloadRecordName = ".slack-cli-downloads.json"
+
+// downloadRecord maps the names of files downloaded into a directory to
+// their Slack file IDs.
+type downloadRecord map[string]string
+
+// loadDownloadRecord reads dir's download record. A missing or unreadable
+// record is treated as empty, so files are downloaded again.
+func loadDownloadRecord(dir string) downloadRecord {
+	record := downloadRecord{}
+	data, err := os.ReadFile(filepath.Join(dir, downloadRecordName))
+	if err == nil {
+		_ = json.Unmarshal(data, &record)
+	}
+	return record
+}
+
+// save writes the record to dir. Failing to save only means files are
+// downloaded again next time, so errors are ignored.
+func (r downloadRecord) save(dir string) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, downloadRecordName), data, 0o644)
+}
+
+// downloadPath picks where to save a file, reporting whether the same Slack
+// file, going by the record of earlier downloads, is already there with the
+// expected size. Any other file with the same name is kept, and the download
+// saved alongside it with the file ID in its name.
+func downloadPath(dir string, file slack.File, record downloadRecord) (string, bool) {
+	name := filepath.Base(fileName(file))
+	if name == "." || name == string(filepath.Separator) || name == downloadRecordName {
+		name = file.ID
+	}
+
+	path := filepath.Join(dir, name)
+	if !fileExists(path) {
+		return path, false
+	}
+	if record[name] == file.ID {
+		return path, sameSize(path, file)
+	}
+
+	ext := filepath.Ext(name)
+	path = filepath.Join(dir, strings.TrimSuffix(name, ext)+"-"+file.ID+ext)
+	return path, record[filepath.Base(path)] == file.ID && sameSize(path, file)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Lstat(path)
+	return err == nil
+}
+
+// sameSize reports whether path is a regular file of the Slack file's size,
+// which catches a recorded download that was since changed or truncated.
+func sameSize(path string, file slack.File) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.Mode().IsRegular() && stat.Size() == int64(file.Size)
+}
+
+func downloadFile(client *slack.Client, file slack.File, path string) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.part")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	progress := newProgress(filepath.Base(path))
+	size, err := client.DownloadFile(file, tmp, progress.update)
+	progress.done()
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return size, os.Rename(tmp.Name(), path)
+}
+
+// progress reports download progress on stderr when it is a terminal.
+type progress struct {
+	name    string
+	enabled bool
+	shown   bool
+	last    int64
+}
+
+func newProgress(name string) *progress {
+	return &progress{name: name, enabled: term.IsTerminal(int(os.Stderr.Fd()))}
+}
+
+func (p *progress) update(written, total int64) {
+	if !p.enabled || (written-p.last < 256*1024 && written != total) {
+		return
+	}
+	p.last = written
+	p.shown = true
+	if total > 0 {
+		fmt.Fprintf(os.Stderr, "\r\033[K%s %3d%% (%s / %s)", p.name, written*100/total, formatSize(written), formatSize(total))
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\r\033[K%s %s", p.name, formatSize(written))
+}
+
+func (p *progress) done() {
+	if p.shown {
+		fmt.Fprint(os.Stderr, "\r\033[K")
+	}
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestFileIDFromRef(t *testing.T) {
+	tests := map[string]string{
+		"F0123ABCD": "F0123ABCD",
+		"https://acme.slack.com/files/U012AB3CD/F0123ABCD/report.pdf":               "F0123ABCD",
+		"https://files.slack.com/files-pri/T012AB3CD-F0123ABCD/download/report.pdf": "F0123ABCD",
+		"https://acme.slack.com/archives/C0123ABCD/p1700000000123456":               "",
+		"report.pdf": "",
+	}
+	for ref, want := range tests {
+		if got := fileIDFromRef(ref); got != want {
+			t.Errorf("fileIDFromRef(%q) = %q, want %q", ref, got, want)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := map[int64]string{
+		0:               "0 B",
+		1023:            "1023 B",
+		1536:            "1.5 KB",
+		5 * 1024 * 1024: "5.0 MB",
+		3 << 30:         "3.0 GB",
+	}
+	for bytes, want := range tests {
+		if got := formatSize(bytes); got != want {
+			t.Errorf("formatSize(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
+
+func TestDownloadPathSkipsRecordedFiles(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "report.pdf"), []byte("12345"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	record := downloadRecord{"report.pdf": "F1"}
+
+	path, existing := downloadPath(dir, slack.File{ID: "F1", Name: "report.pdf", Size: 5}, record)
+	if !existing || path != filepath.Join(dir, "report.pdf") {
+		t.Fatalf("expected the recorded file to be skipped, got %q, %v", path, existing)
+	}
+
+	// Same name and size, but a different Slack file.
+	path, existing = downloadPath(dir, slack.File{ID: "F2", Name: "report.pdf", Size: 5}, record)
+	if existing || path != filepath.Join(dir, "report-F2.pdf") {
+		t.Fatalf("expected a different file to be saved alongside, got %q, %v", path, existing)
+	}
+
+	// Not downloaded by us, so it can't be known to be the same file.
+	path, existing = downloadPath(dir, slack.File{ID: "F1", Name: "report.pdf", Size: 5}, downloadRecord{})
+	if existing || path != filepath.Join(dir, "report-F1.pdf") {
+		t.Fatalf("expected an unrecorded file to be kept and the download saved alongside, got %q, %v", path, existing)
+	}
+
+	path, _ = downloadPath(dir, slack.File{ID: "F3", Name: "../../etc/passwd", Size: 1}, record)
+	if path != filepath.Join(dir, "passwd") {
+		t.Fatalf("expected name to be confined to the directory, got %q", path)
+	}
+}
+
+func TestDownloadRecordRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	downloadRecord{"report.pdf": "F1"}.save(dir)
+
+	if got := loadDownloadRecord(dir)["report.pdf"]; got != "F1" {
+		t.Fatalf("expected the saved record, got %q", got)
+	}
+	if got := loadDownloadRecord(t.TempDir()); len(got) != 0 {
+		t.Fatalf("expected an empty record for a new directory, got %v", got)
+	}
+}
+
+func TestDownloadFilesContinuesAfterAFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.txt" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	client := slack.NewClient("xoxp-test", slack.WithBaseURL(server.URL))
+	files := []slack.File{
+		{ID: "F1", Name: "missing.txt", Size: 5, URLPrivateDownload: server.URL + "/missing.txt"},
+		{ID: "F2", Name: "hello.txt", Size: 5, URLPrivateDownload: server.URL + "/hello.txt"},
+	}
+
+	downloads, err := downloadFiles(client, files, dir)
+	if err == nil || !strings.Contains(err.Error(), "missing.txt") {
+		t.Fatalf("expected an error for missing.txt, got %v", err)
+	}
+	if len(downloads) != 2 {
+		t.Fatalf("expected a result for both files, got %+v", downloads)
+	}
+	if downloads[0].Error == "" || downloads[0].Path != "" {
+		t.Fatalf("expected missing.txt to report its error, got %+v", downloads[0])
+	}
+	if downloads[1].Error != "" || downloads[1].Size != 5 {
+		t.Fatalf("expected hello.txt to download, got %+v", downloads[1])
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "hello.txt")); err != nil || string(data) != "hello" {
+		t.Fatalf("expected hello.txt to be saved, got %q, %v", data, err)
+	}
+}
+
+func TestFileUploadRejectsBinarySnippets(t *testing.T) {
+	c := &FileUploadCmd{Paths: []string{"-"}, Name: "dump.bin", Filetype: "go"}
+	_, cleanup, err := c.uploads(strings.NewReader("\xff\xfe\x00"))
+	cleanup()
+	if err == nil {
+		t.Fatalf("expected binary snippet to be rejected")
+	}
+
+	c = &FileUploadCmd{Paths: []string{"-"}, Name: "main.go", Snippet: true}
+	uploads, cleanup, err := c.uploads(strings.NewReader("package main\n"))
+	cleanup()
+	if err != nil {
+		t.Fatalf("uploads returned error: %v", err)
+	}
+	if len(uploads) != 1 || uploads[0].SnippetType != "text" || uploads[0].Size != 13 || uploads[0].Name != "main.go" {
+		t.Fatalf("unexpected uploads %+v", uploads)
+	}
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lox/slack-cli/internal/output"
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+const (
+	// Polling starts at followMinInterval and slows towards
+	// followMaxInterval while nothing is happening.
+	followMinInterval = 2 * time.Second
+	followMaxInterval = 30 * time.Second
+	// followEditWindow is how many recent messages each poll fetches again so
+	// that edits to them are noticed.
+	followEditWindow = 20
+)
+
+// follow prints initial, a conversation's or thread's messages oldest first,
+// then polls for new and edited messages until interrupted. Structured output
+// is written as NDJSON, one message per line, since a single document can't
+// be streamed.
+func follow(ctx *Context, client *slack.Client, resolver *slack.Resolver, channelID, threadTS string, initial []slack.Message, reactors bool) error {
+	sigCtx, stop := interruptContext()
+	defer stop()
+
+	emit := followPrinter(ctx.format(), resolver, channelID, reactors)
+	if err := emit(initial); err != nil {
+		return err
+	}
+
+	f := newFollower(initial, time.Now())
+	interval := followMinInterval
+	for {
+		select {
+		case <-sigCtx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+
+		messages, err := f.poll(client, channelID, threadTS)
+		if err != nil {
+			var apiErr *slack.APIError
+			if errors.As(err, &apiErr) && apiErr.Code != "" && !slack.IsRateLimited(err) {
+				return fmt.Errorf("failed to poll for messages: %w", err)
+			}
+			// Rate limits and network errors are waited out.
+			interval = followMaxInterval
+			fmt.Fprintf(os.Stderr, "Warning: %v; retrying in %s\n", err, interval)
+			continue
+		}
+
+		updates := f.update(messages)
+		if err := emit(updates); err != nil {
+			return err
+		}
+		interval = nextFollowInterval(interval, len(updates) > 0)
+	}
+}
+
+// interruptContext returns a context that is cancelled by Ctrl-C or SIGTERM,
+// for commands that run until interrupted. A second Ctrl-C exits immediately,
+// even mid-request.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx, stop
+}
+
+// nextFollowInterval polls quickly while messages are arriving and backs off
+// while the conversation is idle.
+func nextFollowInterval(current time.Duration, active bool) time.Duration {
+	if active {
+		return followMinInterval
+	}
+	return min(current*3/2, followMaxInterval)
+}
+
+// followPrinter returns a function that prints a batch of messages in the
+// selected output format.
+func followPrinter(format output.Format, resolver *slack.Resolver, channelID string, reactors bool) func([]slack.Message) error {
+	return func(messages []slack.Message) error {
+		if len(messages) == 0 {
+			return nil
+		}
+		resolver.Prefetch(messages)
+
+		switch {
+		case format.Structured():
+			return output.WriteNDJSON(os.Stdout, newMessagesJSON(resolver, channelID, messages))
+		case format == output.FormatMarkdown:
+			var sb strings.Builder
+			formatter := &messageFormatter{resolver: resolver, reactors: reactors}
+			formatter.writeChannel(&sb, messages)
+			fmt.Print(sb.String())
+		default:
+			printMessages(resolver, messages, reactors)
+		}
+		return nil
+	}
+}
+
+// follower tracks the messages seen while following a conversation, so that
+// each poll reports only new messages and ones that have been edited.
+type follower struct {
+	// recent holds the latest messages seen, oldest first.
+	recent []slack.Message
+	// start is where polling begins if no messages have been seen.
+	start string
+}
+
+func newFollower(initial []slack.Message, now time.Time) *follower {
+	f := &follower{start: slack.FormatTS(now)}
+	f.update(initial)
+	return f
+}
+
+// oldest is the timestamp to poll from: the start of the window of recent
+// messages that are checked for edits.
+func (f *follower) oldest() string {
+	if len(f.recent) == 0 {
+		return f.start
+	}
+	return f.recent[0].TS
+}
+
+func (f *follower) poll(client *slack.Client, channelID, threadTS string) ([]slack.Message, error) {
+	opts := slack.HistoryOptions{Oldest: f.oldest(), Inclusive: true}
+	if threadTS != "" {
+		replies, err := client.GetConversationReplies(channelID, threadTS, opts)
+		if err != nil {
+			return nil, err
+		}
+		return replies.Messages, nil
+	}
+	history, err := client.GetConversationHistory(channelID, opts)
+	if err != nil {
+		return nil, err
+	}
+	return history.Messages, nil
+}
+
+// update records polled messages, returning those that are new or edited
+// since they were last seen, oldest first. Unseen messages from before the
+// window, such as a thread's parent, are ignored.
+func (f *follower) update(messages []slack.Message) []slack.Message {
+	seen := make(map[string]string, len(f.recent))
+	for _, msg := range f.recent {
+		seen[msg.TS] = editedTS(msg)
+	}
+	oldest := f.oldest()
+
+	sorted := append([]slack.Message(nil), messages...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].TS < sorted[j].TS })
+
+	var updates []slack.Message
+	for _, msg := range sorted {
+		edited, ok := seen[msg.TS]
+		switch {
+		case ok && edited == editedTS(msg):
+		case !ok && msg.TS < oldest:
+		default:
+			updates = append(updates, msg)
+		}
+	}
+
+	f.remember(sorted)
+	return updates
+}
+
+// remember merges messages into the window of recent messages.
+func (f *follower) remember(messages []slack.Message) {
+	byTS := make(map[string]slack.Message, len(f.recent)+len(messages))
+	for _, msg := range f.recent {
+		byTS[msg.TS] = msg
+	}
+	for _, msg := range messages {
+		byTS[msg.TS] = msg
+	}
+
+	recent := make([]slack.Message, 0, len(byTS))
+	for _, msg := range byTS {
+		recent = append(recent, msg)
+	}
+	sort.Slice(recent, func(i, j int) bool { return recent[i].TS < recent[j].TS })
+	if len(recent) > followEditWindow {
+		recent = recent[len(recent)-followEditWindow:]
+	}
+	f.recent = recent
+}
+
+func editedTS(msg slack.Message) string {
+	if msg.Edited == nil {
+		return ""
+	}
+	return msg.Edited.TS
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestFollowerReportsNewAndEditedMessages(t *testing.T) {
+	f := newFollower([]slack.Message{{TS: "1.0"}, {TS: "2.0"}}, time.Unix(3, 0))
+
+	// Slack returns newest first; the window's messages come back unchanged.
+	updates := f.update([]slack.Message{{TS: "4.0"}, {TS: "3.0"}, {TS: "2.0"}, {TS: "1.0"}})
+	if got := timestamps(updates); !reflect.DeepEqual(got, []string{"3.0", "4.0"}) {
+		t.Fatalf("expected the new messages oldest first, got %v", got)
+	}
+
+	edited := slack.Message{TS: "2.0", Edited: &slack.Edited{User: "U1", TS: "5.0"}}
+	updates = f.update([]slack.Message{{TS: "4.0"}, {TS: "3.0"}, edited, {TS: "1.0"}})
+	if got := timestamps(updates); !reflect.DeepEqual(got, []string{"2.0"}) {
+		t.Fatalf("expected the edited message, got %v", got)
+	}
+
+	if updates := f.update([]slack.Message{{TS: "4.0"}, {TS: "3.0"}, edited, {TS: "1.0"}}); len(updates) != 0 {
+		t.Fatalf("expected nothing new, got %v", timestamps(updates))
+	}
+}
+
+func TestFollowerIgnoresMessagesBeforeTheWindow(t *testing.T) {
+	f := newFollower(nil, time.Unix(1700000100, 0))
+	if got := f.oldest(); got != "1700000100.000000" {
+		t.Fatalf("expected polling to start now, got %q", got)
+	}
+
+	// A thread's parent is returned with every page of replies.
+	updates := f.update([]slack.Message{{TS: "1700000050.000000"}, {TS: "1700000150.000000"}})
+	if got := timestamps(updates); !reflect.DeepEqual(got, []string{"1700000150.000000"}) {
+		t.Fatalf("expected only the new reply, got %v", got)
+	}
+}
+
+func TestFollowerWindow(t *testing.T) {
+	var messages []slack.Message
+	for i := 0; i < followEditWindow+5; i++ {
+		messages = append(messages, slack.Message{TS: slack.FormatTS(time.Unix(int64(1700000000+i), 0))})
+	}
+	f := newFollower(messages, time.Unix(1700001000, 0))
+
+	if len(f.recent) != followEditWindow {
+		t.Fatalf("expected %d recent messages, got %d", followEditWindow, len(f.recent))
+	}
+	if got, want := f.oldest(), messages[5].TS; got != want {
+		t.Fatalf("expected to poll from %s, got %s", want, got)
+	}
+}
+
+func TestNextFollowInterval(t *testing.T) {
+	interval := followMinInterval
+	for i := 0; i < 20; i++ {
+		interval = nextFollowInterval(interval, false)
+	}
+	if interval != followMaxInterval {
+		t.Fatalf("expected idle polling to back off to %s, got %s", followMaxInterval, interval)
+	}
+	if got := nextFollowInterval(interval, true); got != followMinInterval {
+		t.Fatalf("expected activity to reset the interval, got %s", got)
+	}
+}
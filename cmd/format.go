@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+// messageFormatter renders Slack messages as markdown for view and the
+// markdown output format.
+type messageFormatter struct {
+	resolver *slack.Resolver
+	raw      bool
+	reactors bool
+}
+
+// writeThread writes a thread's parent message followed by its replies as
+// blockquotes.
+func (f *messageFormatter) writeThread(sb *strings.Builder, messages []slack.Message) {
+	for i, msg := range messages {
+		username := f.resolver.ResolveUser(msg.User)
+		timestamp := formatTimestamp(msg.TS)
+		text := f.text(msg)
+
+		if i == 0 {
+			fmt.Fprintf(sb, "**%s** _%s_\n\n", username, timestamp)
+			fmt.Fprintf(sb, "%s\n\n", text)
+			if len(messages) > 1 {
+				fmt.Fprintf(sb, "---\n\n**%d replies**\n\n", len(messages)-1)
+			}
+		} else {
+			fmt.Fprintf(sb, "> **%s** _%s_\n>\n", username, timestamp)
+			// Indent multiline text in blockquote
+			lines := strings.Split(text, "\n")
+			for _, line := range lines {
+				fmt.Fprintf(sb, "> %s\n", line)
+			}
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// writeChannel writes channel messages, which must be ordered oldest first.
+func (f *messageFormatter) writeChannel(sb *strings.Builder, messages []slack.Message) {
+	for _, msg := range messages {
+		username := f.resolver.ResolveUser(msg.User)
+		timestamp := formatTimestamp(msg.TS)
+		text := f.text(msg)
+
+		fmt.Fprintf(sb, "**%s** _%s_\n\n", username, timestamp)
+		fmt.Fprintf(sb, "%s\n\n", text)
+
+		if msg.ReplyCount > 0 {
+			fmt.Fprintf(sb, "_(%d replies)_\n\n", msg.ReplyCount)
+		}
+		sb.WriteString("---\n\n")
+	}
+}
+
+// text renders a message's blocks, or its mrkdwn text if it has none,
+// followed by its files and reactions.
+func (f *messageFormatter) text(msg slack.Message) string {
+	text := msg.Text
+	if !f.raw {
+		text = f.resolver.FormatMessageMarkdown(msg)
+	}
+	if msg.Edited != nil {
+		text += " _(edited)_"
+	}
+	if len(msg.Files) > 0 {
+		var files []string
+		for _, file := range msg.Files {
+			files = append(files, "📎 "+markdownFileLink(file)+" · "+fileDetails(file))
+		}
+		text += "\n\n" + strings.Join(files, "\\\n")
+	}
+	if len(msg.Reactions) > 0 {
+		text += "\n\n" + f.resolver.FormatReactions(msg.Reactions, f.reactors, true)
+	}
+	return text
+}
+
+// printMessages prints messages one per line for the text output format,
+// with any reactions indented on the following line.
+func printMessages(resolver *slack.Resolver, messages []slack.Message, reactors bool) {
+	for _, msg := range messages {
+		user := resolver.ResolveUser(msg.User)
+		text := resolver.FormatMessage(msg)
+		if msg.Edited != nil {
+			text += " (edited)"
+		}
+		fmt.Printf("[%s] %s: %s\n", msg.TS, user, text)
+		for _, file := range msg.Files {
+			fmt.Printf("    📎 %s (%s)\n", fileName(file), fileDetails(file))
+		}
+		if len(msg.Reactions) > 0 {
+			fmt.Printf("    %s\n", resolver.FormatReactions(msg.Reactions, reactors, false))
+		}
+	}
+}
+
+func fileName(file slack.File) string {
+	if file.Name != "" {
+		return file.Name
+	}
+	if file.Title != "" {
+		return file.Title
+	}
+	return file.ID
+}
+
+func markdownFileLink(file slack.File) string {
+	name := slack.EscapeMarkdown(fileName(file))
+	if file.Permalink == "" {
+		return name
+	}
+	return "[" + name + "](" + file.Permalink + ")"
+}
+
+// fileDetails describes a file's type and size, e.g. "PDF, 1.2 MB".
+func fileDetails(file slack.File) string {
+	kind := file.PrettyType
+	if kind == "" {
+		kind = file.Mimetype
+	}
+	if kind == "" {
+		kind = strings.ToUpper(file.Filetype)
+	}
+	if kind == "" {
+		return formatSize(int64(file.Size))
+	}
+	return kind + ", " + formatSize(int64(file.Size))
+}
+
+// formatSize renders a byte count in binary units, e.g. "1.2 MB".
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit && exp < 4; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTP"[exp])
+}
+
+// formatTimestamp renders a Slack timestamp relative to today, e.g. "3:04 PM"
+// for today's messages.
+func formatTimestamp(ts string) string {
+	t, err := slack.ParseTS(ts)
+	if err != nil {
+		return ts
+	}
+
+	now := time.Now()
+	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
+		return t.Format("3:04 PM")
+	}
+	if t.Year() == now.Year() {
+		return t.Format("Jan 2, 3:04 PM")
+	}
+	return t.Format("Jan 2, 2006 3:04 PM")
+}
+
+// oldestFirst returns history messages, which Slack returns newest first, in
+// chronological order.
+func oldestFirst(messages []slack.Message) []slack.Message {
+	ordered := make([]slack.Message, len(messages))
+	for i, msg := range messages {
+		ordered[len(messages)-1-i] = msg
+	}
+	return ordered
+}
@@ -0,0 +1,526 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lox/slack-cli/internal/output"
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+// The types in this file define the JSON emitted by --output json and ndjson.
+// Field names are part of the CLI's interface; add fields rather than rename.
+
+type messageJSON struct {
+	Channel     string           `json:"channel"`
+	TS          string           `json:"ts"`
+	Time        string           `json:"time"`
+	ThreadTS    string           `json:"thread_ts,omitempty"`
+	User        string           `json:"user,omitempty"`
+	UserName    string           `json:"user_name"`
+	Text        string           `json:"text"`
+	RawText     string           `json:"raw_text"`
+	ReplyCount  int              `json:"reply_count,omitempty"`
+	Edited      string           `json:"edited,omitempty"`
+	Permalink   string           `json:"permalink,omitempty"`
+	Files       []fileJSON       `json:"files,omitempty"`
+	Attachments []attachmentJSON `json:"attachments,omitempty"`
+	Reactions   []reactionJSON   `json:"reactions,omitempty"`
+}
+
+type reactionJSON struct {
+	Name  string `json:"name"`
+	Emoji string `json:"emoji"`
+	// URL is the image of a custom emoji.
+	URL   string   `json:"url,omitempty"`
+	Count int      `json:"count"`
+	Users []string `json:"users,omitempty"`
+}
+
+type attachmentJSON struct {
+	Title     string                `json:"title,omitempty"`
+	TitleLink string                `json:"title_link,omitempty"`
+	Pretext   string                `json:"pretext,omitempty"`
+	Author    string                `json:"author,omitempty"`
+	Text      string                `json:"text,omitempty"`
+	Fields    []attachmentFieldJSON `json:"fields,omitempty"`
+	Color     string                `json:"color,omitempty"`
+	Footer    string                `json:"footer,omitempty"`
+	ImageURL  string                `json:"image_url,omitempty"`
+	URL       string                `json:"url,omitempty"`
+	Fallback  string                `json:"fallback,omitempty"`
+}
+
+type attachmentFieldJSON struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type postedMessageJSON struct {
+	Channel   string `json:"channel"`
+	TS        string `json:"ts"`
+	ThreadTS  string `json:"thread_ts,omitempty"`
+	Permalink string `json:"permalink"`
+}
+
+type reactionListJSON struct {
+	Messages   []messageJSON `json:"messages"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type reactedJSON struct {
+	Channel  string `json:"channel"`
+	TS       string `json:"ts"`
+	Reaction string `json:"reaction"`
+	Reacted  bool   `json:"reacted"`
+}
+
+type emojiListJSON struct {
+	Emoji []emojiJSON `json:"emoji"`
+}
+
+type emojiJSON struct {
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	AliasFor string `json:"alias_for,omitempty"`
+}
+
+type cacheWarmJSON struct {
+	Workspace string `json:"workspace"`
+	Users     int    `json:"users"`
+	Channels  int    `json:"channels"`
+}
+
+type cacheStatsJSON struct {
+	Workspaces []cacheWorkspaceJSON `json:"workspaces"`
+}
+
+type cacheWorkspaceJSON struct {
+	Workspace string           `json:"workspace"`
+	Entries   []cacheEntryJSON `json:"entries"`
+}
+
+type cacheEntryJSON struct {
+	Name    string `json:"name"`
+	Items   int    `json:"items"`
+	Size    int64  `json:"size"`
+	SavedAt string `json:"saved_at"`
+}
+
+type deletedMessageJSON struct {
+	Channel string `json:"channel"`
+	TS      string `json:"ts"`
+	Deleted bool   `json:"deleted"`
+}
+
+type fileJSON struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title,omitempty"`
+	Mimetype  string `json:"mimetype,omitempty"`
+	Filetype  string `json:"filetype,omitempty"`
+	Size      int    `json:"size"`
+	URL       string `json:"url,omitempty"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+type fileDownloadJSON struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Skipped bool   `json:"skipped"`
+	// Error is why the file couldn't be downloaded.
+	Error string `json:"error,omitempty"`
+}
+
+type uploadedFilesJSON struct {
+	Channel  string     `json:"channel"`
+	ThreadTS string     `json:"thread_ts,omitempty"`
+	Files    []fileJSON `json:"files"`
+}
+
+type fileDownloadsJSON struct {
+	Files []fileDownloadJSON `json:"files"`
+}
+
+type channelJSON struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	IsPrivate  bool   `json:"is_private"`
+	IsArchived bool   `json:"is_archived"`
+	IsIM       bool   `json:"is_im"`
+	IsMPIM     bool   `json:"is_mpim"`
+	User       string `json:"user,omitempty"`
+	NumMembers int    `json:"num_members"`
+	Topic      string `json:"topic,omitempty"`
+	Purpose    string `json:"purpose,omitempty"`
+}
+
+type userJSON struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	RealName    string `json:"real_name,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Email       string `json:"email,omitempty"`
+	TZ          string `json:"tz,omitempty"`
+	IsBot       bool   `json:"is_bot"`
+	Deleted     bool   `json:"deleted"`
+}
+
+type searchMatchJSON struct {
+	Channel     string `json:"channel"`
+	ChannelName string `json:"channel_name"`
+	TS          string `json:"ts"`
+	Time        string `json:"time"`
+	User        string `json:"user,omitempty"`
+	UserName    string `json:"user_name"`
+	Text        string `json:"text"`
+	RawText     string `json:"raw_text"`
+	Permalink   string `json:"permalink,omitempty"`
+	ThreadTS    string `json:"thread_ts,omitempty"`
+	// ContextBefore and ContextAfter hold the messages around the match
+	// with search --context, and Thread its thread with --with-thread.
+	ContextBefore []messageJSON `json:"context_before,omitempty"`
+	ContextAfter  []messageJSON `json:"context_after,omitempty"`
+	Thread        []messageJSON `json:"thread,omitempty"`
+}
+
+type channelListJSON struct {
+	Channels   []channelJSON `json:"channels"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type userListJSON struct {
+	Users      []userJSON `json:"users"`
+	HasMore    bool       `json:"has_more"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+type messagesJSON struct {
+	Channel    channelJSON   `json:"channel"`
+	ThreadTS   string        `json:"thread_ts,omitempty"`
+	Messages   []messageJSON `json:"messages"`
+	HasMore    bool          `json:"has_more"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+type searchPagingJSON struct {
+	Total   int  `json:"total"`
+	Page    int  `json:"page"`
+	Pages   int  `json:"pages"`
+	PerPage int  `json:"per_page"`
+	HasMore bool `json:"has_more"`
+}
+
+type searchJSON struct {
+	Query string `json:"query,omitempty"`
+	searchPagingJSON
+	Matches []searchMatchJSON `json:"matches"`
+}
+
+type searchFileJSON struct {
+	fileJSON
+	User     string   `json:"user,omitempty"`
+	UserName string   `json:"user_name,omitempty"`
+	Created  string   `json:"created,omitempty"`
+	Channels []string `json:"channels,omitempty"`
+}
+
+type searchFilesJSON struct {
+	Query string `json:"query,omitempty"`
+	searchPagingJSON
+	Files []searchFileJSON `json:"files"`
+}
+
+type searchAllJSON struct {
+	Query    string          `json:"query"`
+	Messages searchJSON      `json:"messages"`
+	Files    searchFilesJSON `json:"files"`
+}
+
+// searchAllMessageJSON and searchAllFileJSON are the lines of search all's
+// NDJSON output, which mixes the two; type is "message" or "file".
+type searchAllMessageJSON struct {
+	Type string `json:"type"`
+	searchMatchJSON
+}
+
+type searchAllFileJSON struct {
+	Type string `json:"type"`
+	searchFileJSON
+}
+
+type authStatusJSON struct {
+	LoggedIn   bool            `json:"logged_in"`
+	Error      string          `json:"error,omitempty"`
+	Workspace  string          `json:"workspace,omitempty"`
+	URL        string          `json:"url,omitempty"`
+	Team       string          `json:"team,omitempty"`
+	TeamID     string          `json:"team_id,omitempty"`
+	User       string          `json:"user,omitempty"`
+	UserID     string          `json:"user_id,omitempty"`
+	Workspaces []workspaceJSON `json:"workspaces,omitempty"`
+}
+
+type workspaceJSON struct {
+	Workspace string `json:"workspace"`
+	URL       string `json:"url"`
+	Default   bool   `json:"default"`
+}
+
+// eventJSON is an event received by the listen command. Event holds the
+// event exactly as Slack sent it.
+type eventJSON struct {
+	Type        string          `json:"type"`
+	Subtype     string          `json:"subtype,omitempty"`
+	Time        string          `json:"time,omitempty"`
+	Channel     string          `json:"channel,omitempty"`
+	ChannelName string          `json:"channel_name,omitempty"`
+	User        string          `json:"user,omitempty"`
+	UserName    string          `json:"user_name,omitempty"`
+	TS          string          `json:"ts,omitempty"`
+	ThreadTS    string          `json:"thread_ts,omitempty"`
+	Text        string          `json:"text,omitempty"`
+	RawText     string          `json:"raw_text,omitempty"`
+	Reaction    string          `json:"reaction,omitempty"`
+	ItemTS      string          `json:"item_ts,omitempty"`
+	Event       json.RawMessage `json:"event"`
+}
+
+// writeStructured emits doc as JSON, or each of items on its own line for
+// NDJSON.
+func writeStructured[T any](format output.Format, doc any, items []T) error {
+	if format == output.FormatNDJSON {
+		return output.WriteNDJSON(os.Stdout, items)
+	}
+	return output.WriteJSON(os.Stdout, doc)
+}
+
+// isoTime converts a Slack timestamp to RFC 3339 in UTC.
+func isoTime(ts string) string {
+	t, err := slack.ParseTS(ts)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func newMessageJSON(resolver *slack.Resolver, channelID string, msg slack.Message) messageJSON {
+	threadTS := msg.ThreadTS
+	if threadTS == msg.TS && msg.ReplyCount == 0 {
+		threadTS = ""
+	}
+
+	m := messageJSON{
+		Channel:    channelID,
+		TS:         msg.TS,
+		Time:       isoTime(msg.TS),
+		ThreadTS:   threadTS,
+		User:       msg.User,
+		UserName:   resolver.ResolveUser(msg.User),
+		Text:       resolver.FormatMessage(msg),
+		RawText:    msg.Text,
+		ReplyCount: msg.ReplyCount,
+		Permalink:  resolver.Permalink(channelID, msg.TS, msg.ThreadTS),
+	}
+	if msg.Edited != nil {
+		m.Edited = isoTime(msg.Edited.TS)
+	}
+	for _, f := range msg.Files {
+		m.Files = append(m.Files, newFileJSON(f))
+	}
+	for _, att := range msg.Attachments {
+		m.Attachments = append(m.Attachments, newAttachmentJSON(resolver, att))
+	}
+	for _, reaction := range msg.Reactions {
+		_, url, _ := resolver.Emoji(reaction.Name)
+		m.Reactions = append(m.Reactions, reactionJSON{
+			Name:  reaction.Name,
+			Emoji: resolver.FormatEmoji(reaction.Name),
+			URL:   url,
+			Count: reaction.Count,
+			Users: reaction.Users,
+		})
+	}
+	return m
+}
+
+func newMessagesJSON(resolver *slack.Resolver, channelID string, messages []slack.Message) []messageJSON {
+	out := make([]messageJSON, 0, len(messages))
+	for _, msg := range messages {
+		out = append(out, newMessageJSON(resolver, channelID, msg))
+	}
+	return out
+}
+
+func newFileJSON(f slack.File) fileJSON {
+	return fileJSON{
+		ID:        f.ID,
+		Name:      f.Name,
+		Title:     f.Title,
+		Mimetype:  f.Mimetype,
+		Filetype:  f.Filetype,
+		Size:      f.Size,
+		URL:       f.URLPrivate,
+		Permalink: f.Permalink,
+	}
+}
+
+func newAttachmentJSON(resolver *slack.Resolver, att slack.Attachment) attachmentJSON {
+	author := att.AuthorName
+	if author == "" {
+		author = att.ServiceName
+	}
+	url := att.OriginalURL
+	if url == "" {
+		url = att.FromURL
+	}
+
+	a := attachmentJSON{
+		Title:     att.Title,
+		TitleLink: att.TitleLink,
+		Pretext:   resolver.FormatText(att.Pretext),
+		Author:    author,
+		Text:      resolver.FormatText(att.Text),
+		Color:     att.Color,
+		Footer:    resolver.FormatText(att.Footer),
+		ImageURL:  att.ImageURL,
+		URL:       url,
+		Fallback:  att.Fallback,
+	}
+	for _, field := range att.Fields {
+		a.Fields = append(a.Fields, attachmentFieldJSON{
+			Title: field.Title,
+			Value: resolver.FormatText(field.Value),
+		})
+	}
+	return a
+}
+
+func newChannelJSON(ch slack.Channel) channelJSON {
+	return channelJSON{
+		ID:         ch.ID,
+		Name:       ch.Name,
+		IsPrivate:  ch.IsPrivate,
+		IsArchived: ch.IsArchived,
+		IsIM:       ch.IsIM,
+		IsMPIM:     ch.IsMPIM,
+		User:       ch.User,
+		NumMembers: ch.NumMembers,
+		Topic:      ch.Topic.Value,
+		Purpose:    ch.Purpose.Value,
+	}
+}
+
+func newUserJSON(u slack.User) userJSON {
+	return userJSON{
+		ID:          u.ID,
+		Name:        u.Name,
+		RealName:    u.RealName,
+		DisplayName: u.Profile.DisplayName,
+		Title:       u.Profile.Title,
+		Email:       u.Profile.Email,
+		TZ:          u.TZ,
+		IsBot:       u.IsBot,
+		Deleted:     u.Deleted,
+	}
+}
+
+func newSearchJSON(resolver *slack.Resolver, query string, results searchResults[slack.SearchMatch], hits []searchHit) searchJSON {
+	doc := searchJSON{
+		Query:            query,
+		searchPagingJSON: results.pagingJSON(),
+		Matches:          make([]searchMatchJSON, 0, len(hits)),
+	}
+	for _, hit := range hits {
+		doc.Matches = append(doc.Matches, newSearchMatchJSON(resolver, hit))
+	}
+	return doc
+}
+
+func newSearchMatchJSON(resolver *slack.Resolver, hit searchHit) searchMatchJSON {
+	match := hit.Match
+	text := stripHighlights(match.Text)
+	userName := match.Username
+	if match.User != "" {
+		userName = resolver.ResolveUser(match.User)
+	}
+
+	m := searchMatchJSON{
+		Channel:     match.Channel.ID,
+		ChannelName: match.Channel.Name,
+		TS:          match.TS,
+		Time:        isoTime(match.TS),
+		User:        match.User,
+		UserName:    userName,
+		Text:        resolver.FormatText(text),
+		RawText:     text,
+		Permalink:   match.Permalink,
+		ThreadTS:    hit.ThreadTS,
+	}
+	if len(hit.Before) > 0 {
+		m.ContextBefore = newMessagesJSON(resolver, match.Channel.ID, hit.Before)
+	}
+	if len(hit.After) > 0 {
+		m.ContextAfter = newMessagesJSON(resolver, match.Channel.ID, hit.After)
+	}
+	if len(hit.Thread) > 0 {
+		m.Thread = newMessagesJSON(resolver, match.Channel.ID, hit.Thread)
+	}
+	return m
+}
+
+func newSearchFilesJSON(resolver *slack.Resolver, query string, results searchResults[slack.File]) searchFilesJSON {
+	doc := searchFilesJSON{
+		Query:            query,
+		searchPagingJSON: results.pagingJSON(),
+		Files:            make([]searchFileJSON, 0, len(results.Matches)),
+	}
+	for _, file := range results.Matches {
+		f := searchFileJSON{
+			fileJSON: newFileJSON(file),
+			User:     file.User,
+			Channels: file.Conversations(),
+		}
+		if file.User != "" {
+			f.UserName = resolver.ResolveUser(file.User)
+		}
+		if file.Created > 0 {
+			f.Created = time.Unix(file.Created, 0).UTC().Format(time.RFC3339)
+		}
+		doc.Files = append(doc.Files, f)
+	}
+	return doc
+}
+
+func newEventJSON(resolver *slack.Resolver, event slack.Event) eventJSON {
+	e := eventJSON{
+		Type:     event.Type,
+		Subtype:  event.Subtype,
+		Time:     isoTime(eventTS(event)),
+		Channel:  event.Channel,
+		User:     event.User,
+		TS:       event.TS,
+		ThreadTS: event.ThreadTS,
+		RawText:  event.Text,
+		Reaction: event.Reaction,
+		ItemTS:   event.ItemTS,
+		Event:    event.Raw,
+	}
+	if !strings.HasPrefix(event.Channel, "D") {
+		e.ChannelName = eventChannelName(resolver, event)
+	}
+	if event.User != "" {
+		e.UserName = resolver.ResolveUser(event.User)
+	}
+	if event.Text != "" {
+		e.Text = resolver.FormatText(event.Text)
+	}
+	return e
+}
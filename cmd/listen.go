@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/lox/slack-cli/internal/output"
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+type ListenCmd struct {
+	Channel  []string `help:"Only events in these conversations (#channel, name or ID)" placeholder:"CHANNEL"`
+	User     []string `help:"Only events from these people (@username, email or user ID)" placeholder:"USER"`
+	Type     []string `help:"Only these event types or message subtypes, e.g. message, reaction_added, channel_created, message_changed" placeholder:"TYPE"`
+	AppToken string   `help:"App-level token (xapp-...) to connect with instead of the one saved by 'auth app-token'" env:"SLACK_APP_TOKEN"`
+	Endpoint string   `help:"Socket Mode WebSocket URL to connect to instead of asking Slack for one, e.g. a local stand-in for testing" env:"SLACK_SOCKET_URL" placeholder:"URL"`
+}
+
+func (c *ListenCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+	resolver := ctx.newResolver(client)
+
+	appToken := strings.TrimSpace(c.AppToken)
+	if appToken == "" && c.Endpoint == "" {
+		if appToken, _, err = ctx.Config.AppTokenForWorkspace(ctx.workspaceKey); err != nil {
+			return err
+		}
+	}
+
+	filter, err := c.filter(ctx, client, resolver)
+	if err != nil {
+		return err
+	}
+
+	appClient, err := ctx.newSlackClient(appToken)
+	if err != nil {
+		return err
+	}
+	socket := slack.NewSocketMode(appClient)
+	socket.URL = c.Endpoint
+	socket.Logf = func(format string, args ...any) {
+		fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+	}
+
+	sigCtx, stop := interruptContext()
+	defer stop()
+
+	format := ctx.format()
+	fmt.Fprintln(os.Stderr, "Listening for events; press Ctrl-C to stop.")
+	var writeErr error
+	err = socket.Run(sigCtx, func(event slack.Event) {
+		if writeErr != nil || !filter.match(event) {
+			return
+		}
+		if format.Structured() {
+			writeErr = output.WriteNDJSON(os.Stdout, []eventJSON{newEventJSON(resolver, event)})
+		} else {
+			fmt.Println(describeEvent(resolver, event))
+		}
+		if writeErr != nil {
+			stop()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to listen for events: %w", err)
+	}
+	return writeErr
+}
+
+// filter resolves the --channel, --user and --type flags.
+func (c *ListenCmd) filter(ctx *Context, client *slack.Client, resolver *slack.Resolver) (eventFilter, error) {
+	var f eventFilter
+	for _, ref := range c.Channel {
+		id, err := ctx.resolveChannelID(client, ref)
+		if err != nil {
+			return f, err
+		}
+		f.channels = appendSet(f.channels, id)
+	}
+	for _, ref := range c.User {
+		user, err := lookupUser(client, resolver, ref)
+		if err != nil {
+			return f, fmt.Errorf("failed to find user %s: %w", ref, err)
+		}
+		f.users = appendSet(f.users, user.ID)
+	}
+	for _, t := range c.Type {
+		f.types = appendSet(f.types, strings.ToLower(strings.TrimSpace(t)))
+	}
+	return f, nil
+}
+
+// eventFilter matches events against the listen command's filters. An empty
+// set matches everything.
+type eventFilter struct {
+	channels map[string]bool
+	users    map[string]bool
+	types    map[string]bool
+}
+
+func (f eventFilter) match(event slack.Event) bool {
+	if len(f.channels) > 0 && !f.channels[event.Channel] {
+		return false
+	}
+	if len(f.users) > 0 && !f.users[event.User] {
+		return false
+	}
+	if len(f.types) > 0 && !f.types[event.Type] && (event.Subtype == "" || !f.types[event.Subtype]) {
+		return false
+	}
+	return true
+}
+
+func appendSet(set map[string]bool, key string) map[string]bool {
+	if set == nil {
+		set = make(map[string]bool)
+	}
+	set[key] = true
+	return set
+}
+
+// eventTS is when an event happened.
+func eventTS(event slack.Event) string {
+	if event.EventTS != "" {
+		return event.EventTS
+	}
+	return event.TS
+}
+
+// eventChannelName is the name of the conversation an event happened in,
+// without a leading #.
+func eventChannelName(resolver *slack.Resolver, event slack.Event) string {
+	switch {
+	case event.ChannelName != "":
+		return event.ChannelName
+	case event.Channel == "":
+		return ""
+	}
+	return resolver.ResolveChannel(event.Channel)
+}
+
+// describeEvent formats an event as a single line for the text output
+// format, e.g. "[1700000000.000100] #general alice: hello".
+func describeEvent(resolver *slack.Resolver, event slack.Event) string {
+	var where string
+	switch {
+	case strings.HasPrefix(event.Channel, "D"):
+		where = "DM "
+	case event.Channel != "" || event.ChannelName != "":
+		where = "#" + eventChannelName(resolver, event) + " "
+	}
+	var who string
+	if event.User != "" {
+		who = resolver.ResolveUser(event.User)
+	}
+
+	var line string
+	switch {
+	case event.Type == "message" && event.Subtype == "message_deleted":
+		line = where + "message deleted"
+	case event.Type == "message":
+		author := resolver.ResolveUser(event.User)
+		if event.Subtype == "message_changed" {
+			author += " (edited)"
+		}
+		line = where + author + ": " + resolver.FormatText(event.Text)
+	case event.Type == "reaction_added":
+		line = fmt.Sprintf("%s%s reacted :%s: to %s", where, who, event.Reaction, event.ItemTS)
+	case event.Type == "reaction_removed":
+		line = fmt.Sprintf("%s%s removed :%s: from %s", where, who, event.Reaction, event.ItemTS)
+	default:
+		line = event.Type + " " + where
+		if who != "" {
+			line += "by " + who
+		}
+	}
+	return fmt.Sprintf("[%s] %s", eventTS(event), strings.TrimSpace(line))
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestEventFilterMatch(t *testing.T) {
+	f := eventFilter{
+		channels: map[string]bool{"C1": true},
+		users:    map[string]bool{"U1": true},
+		types:    map[string]bool{"reaction_added": true, "message_changed": true},
+	}
+
+	tests := []struct {
+		name  string
+		event slack.Event
+		want  bool
+	}{
+		{"matching reaction", slack.Event{Type: "reaction_added", Channel: "C1", User: "U1"}, true},
+		{"matching message subtype", slack.Event{Type: "message", Subtype: "message_changed", Channel: "C1", User: "U1"}, true},
+		{"other type", slack.Event{Type: "message", Channel: "C1", User: "U1"}, false},
+		{"other channel", slack.Event{Type: "reaction_added", Channel: "C2", User: "U1"}, false},
+		{"other user", slack.Event{Type: "reaction_added", Channel: "C1", User: "U2"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := f.match(tt.event); got != tt.want {
+				t.Fatalf("match(%+v) = %v, want %v", tt.event, got, tt.want)
+			}
+		})
+	}
+
+	if !(eventFilter{}).match(slack.Event{Type: "channel_created"}) {
+		t.Fatal("expected an empty filter to match everything")
+	}
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+type MessageCmd struct {
+	Send   MessageSendCmd   `cmd:"" help:"Post a message to a channel"`
+	Edit   MessageEditCmd   `cmd:"" help:"Edit one of your messages"`
+	Delete MessageDeleteCmd `cmd:"" help:"Delete one of your messages"`
+}
+
+type MessageSendCmd struct {
+	Channel string `arg:"" help:"Channel name or ID"`
+
+	Input messageInput `embed:""`
+}
+
+func (c *MessageSendCmd) Run(ctx *Context) error {
+	text, opts, err := c.Input.compose(os.Stdin)
+	if err != nil {
+		return err
+	}
+
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+
+	channelID, err := ctx.resolveChannelID(client, c.Channel)
+	if err != nil {
+		return err
+	}
+
+	return postMessage(ctx, client, channelID, text, opts)
+}
+
+type MessageEditCmd struct {
+	URL    string `arg:"" help:"Message URL"`
+	DryRun bool   `help:"Show the changes without making them"`
+	Yes    bool   `help:"Don't ask for confirmation" short:"y"`
+
+	Input messageInput `embed:""`
+}
+
+func (c *MessageEditCmd) Run(ctx *Context) error {
+	text, opts, err := c.Input.compose(os.Stdin)
+	if err != nil {
+		return err
+	}
+	if !c.Yes && !c.DryRun && (c.Input.Text == "-" || c.Input.AsBlocks == "-") {
+		return fmt.Errorf("pass --yes when reading the message from stdin, since confirmation also reads stdin")
+	}
+
+	client, err := ctx.NewClient(c.URL)
+	if err != nil {
+		return err
+	}
+
+	info, msg, err := ownMessage(ctx, client, c.URL)
+	if err != nil {
+		return err
+	}
+
+	proceed, err := previewChange(lineDiff(msg.Text, text), "Edit this message?", c.DryRun, c.Yes)
+	if !proceed || err != nil {
+		return err
+	}
+
+	if err := client.UpdateMessage(info.Channel, info.MessageTS, text, opts.Blocks); err != nil {
+		return fmt.Errorf("failed to edit message: %w", err)
+	}
+
+	permalink, err := client.GetPermalink(info.Channel, info.MessageTS)
+	if err != nil {
+		return fmt.Errorf("message edited, but failed to get its permalink: %w", err)
+	}
+	if format := ctx.format(); format.Structured() {
+		doc := postedMessageJSON{Channel: info.Channel, TS: info.MessageTS, Permalink: permalink}
+		if msg.ThreadTS != msg.TS {
+			doc.ThreadTS = msg.ThreadTS
+		}
+		return writeStructured(format, doc, []postedMessageJSON{doc})
+	}
+	fmt.Println(permalink)
+	return nil
+}
+
+type MessageDeleteCmd struct {
+	URL    string `arg:"" help:"Message URL"`
+	DryRun bool   `help:"Show the message without deleting it"`
+	Yes    bool   `help:"Don't ask for confirmation" short:"y"`
+}
+
+func (c *MessageDeleteCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient(c.URL)
+	if err != nil {
+		return err
+	}
+
+	info, msg, err := ownMessage(ctx, client, c.URL)
+	if err != nil {
+		return err
+	}
+
+	proceed, err := previewChange(lineDiff(msg.Text, ""), "Delete this message?", c.DryRun, c.Yes)
+	if !proceed || err != nil {
+		return err
+	}
+
+	if err := client.DeleteMessage(info.Channel, info.MessageTS); err != nil {
+		return fmt.Errorf("failed to delete message: %w", err)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := deletedMessageJSON{Channel: info.Channel, TS: info.MessageTS, Deleted: true}
+		return writeStructured(format, doc, []deletedMessageJSON{doc})
+	}
+	fmt.Println("Message deleted")
+	return nil
+}
+
+// ownMessage fetches the message a permalink points to, returning a
+// NotAuthorError unless the authenticated user wrote it.
+func ownMessage(ctx *Context, client *slack.Client, rawURL string) (*slackURLInfo, *slack.Message, error) {
+	info, err := parseSlackURL(rawURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse message URL: %w", err)
+	}
+	if info.MessageTS == "" {
+		return nil, nil, fmt.Errorf("URL does not link to a message: %s", rawURL)
+	}
+
+	msg, err := client.GetMessage(info.Channel, info.MessageTS, info.ThreadTS)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get message: %w", ctx.augmentChannelNotFoundError(rawURL, err))
+	}
+
+	auth, err := client.AuthTest()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to identify the authenticated user: %w", err)
+	}
+	if msg.User != auth.UserID {
+		return nil, nil, &NotAuthorError{Channel: info.Channel, TS: info.MessageTS, Author: msg.User, User: auth.UserID}
+	}
+
+	return info, msg, nil
+}
+
+// previewChange shows a diff of a change to a message and reports whether to
+// go ahead with it. Dry runs print the diff to stdout and stop; otherwise it
+// goes to stderr, followed by a confirmation prompt unless yes is set.
+func previewChange(diff, question string, dryRun, yes bool) (bool, error) {
+	if dryRun {
+		fmt.Print(diff)
+		return false, nil
+	}
+
+	fmt.Fprint(os.Stderr, diff)
+	if yes {
+		return true, nil
+	}
+
+	ok, err := confirm(bufio.NewReader(os.Stdin), os.Stderr, question)
+	if err != nil {
+		return false, fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !ok {
+		return false, fmt.Errorf("cancelled")
+	}
+	return true, nil
+}
+
+// confirm asks a yes/no question, defaulting to no.
+func confirm(reader *bufio.Reader, w io.Writer, question string) (bool, error) {
+	fmt.Fprintf(w, "%s [y/N]: ", question)
+	choice, err := reader.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	choice = strings.TrimSpace(choice)
+	return strings.EqualFold(choice, "y") || strings.EqualFold(choice, "yes"), nil
+}
+
+// messageInput holds the message content flags shared by commands that post
+// messages.
+type messageInput struct {
+	Text     string `arg:"" optional:"" help:"Message text in Slack mrkdwn, or - to read it from stdin"`
+	Markdown bool   `help:"Convert the text from Markdown to Slack mrkdwn"`
+	AsBlocks string `help:"Post Block Kit blocks from a JSON file (- for stdin); the text becomes the notification fallback" placeholder:"FILE"`
+}
+
+// compose returns the text and options to post, reading the text or blocks
+// from stdin when given as "-".
+func (m *messageInput) compose(stdin io.Reader) (string, slack.PostMessageOptions, error) {
+	var opts slack.PostMessageOptions
+
+	if m.Text == "-" && m.AsBlocks == "-" {
+		return "", opts, fmt.Errorf("only one of the text and --as-blocks can be read from stdin")
+	}
+
+	text := m.Text
+	if text == "-" {
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return "", opts, fmt.Errorf("failed to read message from stdin: %w", err)
+		}
+		text = strings.TrimSuffix(string(data), "\n")
+	}
+	if m.Markdown {
+		text = slack.MarkdownToMrkdwn(text)
+	}
+
+	if m.AsBlocks != "" {
+		blocks, err := readBlocks(m.AsBlocks, stdin)
+		if err != nil {
+			return "", opts, err
+		}
+		opts.Blocks = blocks
+	}
+
+	if strings.TrimSpace(text) == "" && len(opts.Blocks) == 0 {
+		return "", opts, fmt.Errorf("message text is empty; pass it as an argument or use - to read it from stdin")
+	}
+
+	return text, opts, nil
+}
+
+// readBlocks reads a JSON array of Block Kit blocks, also accepting the
+// {"blocks": [...]} payloads that Block Kit Builder produces.
+func readBlocks(path string, stdin io.Reader) (json.RawMessage, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blocks: %w", err)
+	}
+
+	var payload struct {
+		Blocks json.RawMessage `json:"blocks"`
+	}
+	if err := json.Unmarshal(data, &payload); err == nil && len(payload.Blocks) > 0 {
+		data = payload.Blocks
+	}
+
+	var blocks []json.RawMessage
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return nil, fmt.Errorf("blocks must be a JSON array of Block Kit blocks: %w", err)
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("blocks file contains no blocks")
+	}
+
+	return json.RawMessage(data), nil
+}
+
+// postMessage posts a message and prints its permalink.
+func postMessage(ctx *Context, client *slack.Client, channelID, text string, opts slack.PostMessageOptions) error {
+	resp, err := client.PostMessage(channelID, text, opts)
+	if err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+
+	permalink, err := client.GetPermalink(resp.Channel, resp.TS)
+	if err != nil {
+		return fmt.Errorf("message posted, but failed to get its permalink: %w", err)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := postedMessageJSON{
+			Channel:   resp.Channel,
+			TS:        resp.TS,
+			ThreadTS:  opts.ThreadTS,
+			Permalink: permalink,
+		}
+		return writeStructured(format, doc, []postedMessageJSON{doc})
+	}
+
+	fmt.Println(permalink)
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMessageInputReadsTextFromStdin(t *testing.T) {
+	input := messageInput{Text: "-", Markdown: true}
+
+	text, opts, err := input.compose(strings.NewReader("**shipped** v1.2\n"))
+	if err != nil {
+		t.Fatalf("compose returned error: %v", err)
+	}
+	if text != "*shipped* v1.2" {
+		t.Fatalf("expected converted stdin text, got %q", text)
+	}
+	if len(opts.Blocks) != 0 {
+		t.Fatalf("expected no blocks, got %s", opts.Blocks)
+	}
+}
+
+func TestMessageInputRequiresContent(t *testing.T) {
+	input := messageInput{Text: "-"}
+	if _, _, err := input.compose(strings.NewReader("\n")); err == nil {
+		t.Fatalf("expected error for empty message")
+	}
+}
+
+func TestMessageInputReadsBlocks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "blocks.json")
+	payload := `{"blocks": [{"type": "section", "text": {"type": "mrkdwn", "text": "hi"}}]}`
+	if err := os.WriteFile(path, []byte(payload), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	input := messageInput{AsBlocks: path}
+	text, opts, err := input.compose(strings.NewReader(""))
+	if err != nil {
+		t.Fatalf("compose returned error: %v", err)
+	}
+	if text != "" {
+		t.Fatalf("expected no fallback text, got %q", text)
+	}
+	if !strings.HasPrefix(string(opts.Blocks), "[") {
+		t.Fatalf("expected the blocks array to be unwrapped, got %s", opts.Blocks)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"type": "section"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := input.compose(strings.NewReader("")); err == nil {
+		t.Fatalf("expected error for a blocks file that isn't an array")
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	got := lineDiff("deploy done\nall green\nthanks", "deploy done\nsome red\nthanks")
+	want := "  deploy done\n- all green\n+ some red\n  thanks\n"
+	if got != want {
+		t.Fatalf("lineDiff\n got: %q\nwant: %q", got, want)
+	}
+
+	if got := lineDiff("bye", ""); got != "- bye\n" {
+		t.Fatalf("expected deletion diff, got %q", got)
+	}
+}
+
+func TestConfirm(t *testing.T) {
+	for input, want := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		got, err := confirm(bufio.NewReader(strings.NewReader(input)), io.Discard, "Sure?")
+		if err != nil {
+			t.Fatalf("confirm(%q) returned error: %v", input, err)
+		}
+		if got != want {
+			t.Errorf("confirm(%q) = %v, want %v", input, got, want)
+		}
+	}
+}
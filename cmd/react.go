@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+type ReactCmd struct {
+	Add    ReactAddCmd    `cmd:"" help:"Add an emoji reaction to a message"`
+	Remove ReactRemoveCmd `cmd:"" help:"Remove your emoji reaction from a message"`
+	List   ReactListCmd   `cmd:"" help:"List messages you've reacted to"`
+}
+
+type ReactAddCmd struct {
+	URL   string `arg:"" help:"Message URL"`
+	Emoji string `arg:"" help:"Emoji name, with or without colons (e.g. eyes or :white_check_mark:)"`
+}
+
+func (c *ReactAddCmd) Run(ctx *Context) error {
+	return react(ctx, c.URL, c.Emoji, true)
+}
+
+type ReactRemoveCmd struct {
+	URL   string `arg:"" help:"Message URL"`
+	Emoji string `arg:"" help:"Emoji name, with or without colons"`
+}
+
+func (c *ReactRemoveCmd) Run(ctx *Context) error {
+	return react(ctx, c.URL, c.Emoji, false)
+}
+
+// react adds or removes a reaction. Adding a reaction that is already there,
+// or removing one that isn't, succeeds without changing anything.
+func react(ctx *Context, rawURL, emoji string, add bool) error {
+	info, err := parseSlackURL(rawURL)
+	if err != nil {
+		return fmt.Errorf("failed to parse message URL: %w", err)
+	}
+	if info.MessageTS == "" {
+		return fmt.Errorf("URL does not link to a message: %s", rawURL)
+	}
+
+	name := strings.Trim(strings.TrimSpace(emoji), ":")
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return fmt.Errorf("invalid emoji name %q", emoji)
+	}
+
+	client, err := ctx.NewClient(rawURL)
+	if err != nil {
+		return err
+	}
+
+	var apiErr *slack.APIError
+	if add {
+		err = client.AddReaction(info.Channel, info.MessageTS, name)
+		if errors.As(err, &apiErr) && apiErr.Code == "already_reacted" {
+			err = nil
+		}
+	} else {
+		err = client.RemoveReaction(info.Channel, info.MessageTS, name)
+		if errors.As(err, &apiErr) && apiErr.Code == "no_reaction" {
+			err = nil
+		}
+	}
+	if err != nil {
+		err = ctx.augmentChannelNotFoundError(rawURL, err)
+		return fmt.Errorf("failed to update reaction: %w", err)
+	}
+
+	if format := ctx.format(); format.Structured() {
+		doc := reactedJSON{Channel: info.Channel, TS: info.MessageTS, Reaction: name, Reacted: add}
+		return writeStructured(format, doc, []reactedJSON{doc})
+	}
+
+	resolver := ctx.newResolver(client)
+	if add {
+		fmt.Printf("Reacted with %s\n", resolver.FormatEmoji(name))
+	} else {
+		fmt.Printf("Removed %s reaction\n", resolver.FormatEmoji(name))
+	}
+	return nil
+}
+
+type ReactListCmd struct {
+	Limit    int  `help:"Maximum number of messages to list (0 for all)" default:"20"`
+	Reactors bool `help:"Show who reacted to each message"`
+}
+
+func (c *ReactListCmd) Run(ctx *Context) error {
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+	resolver := ctx.newResolver(client)
+
+	resp, err := client.ListReactions(c.Limit)
+	if err != nil {
+		return fmt.Errorf("failed to list reactions: %w", err)
+	}
+	messages := make([]slack.Message, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		messages = append(messages, item.Message)
+	}
+	resolver.Prefetch(messages)
+
+	if format := ctx.format(); format.Structured() {
+		doc := reactionListJSON{
+			Messages:   make([]messageJSON, 0, len(resp.Items)),
+			HasMore:    resp.HasMore,
+			NextCursor: resp.ResponseMetadata.NextCursor,
+		}
+		for _, item := range resp.Items {
+			if item.Type != "message" {
+				continue
+			}
+			doc.Messages = append(doc.Messages, newMessageJSON(resolver, item.Channel, item.Message))
+		}
+		return writeStructured(format, doc, doc.Messages)
+	}
+
+	for _, item := range resp.Items {
+		if item.Type != "message" {
+			continue
+		}
+		msg := item.Message
+		fmt.Printf("[%s] #%s %s: %s\n", msg.TS, resolver.ResolveChannel(item.Channel), resolver.ResolveUser(msg.User), resolver.FormatMessage(msg))
+		if len(msg.Reactions) > 0 {
+			fmt.Printf("    %s\n", resolver.FormatReactions(msg.Reactions, c.Reactors, false))
+		}
+	}
+
+	if resp.HasMore {
+		printMoreHint("reactions")
+	}
+
+	return nil
+}
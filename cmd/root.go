@@ -1,28 +1,167 @@
 package cmd
 
 import (
+	"cmp"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"strings"
 
+	"github.com/lox/slack-cli/internal/cache"
 	"github.com/lox/slack-cli/internal/config"
+	"github.com/lox/slack-cli/internal/output"
 	"github.com/lox/slack-cli/internal/slack"
 )
 
 type Context struct {
 	Config    *config.Config
 	Workspace string
+	Output    output.Format
+	NoCache   bool
+	Version   string
+
+	// clientOpts configure every Slack client the command creates; see
+	// clientOptions.
+	clientOpts []slack.Option
+	// workspaceKey is the configured workspace the last client was created
+	// for, used to keep cached lookups apart.
+	workspaceKey string
+	resolvers    []*slack.Resolver
+}
+
+// format returns the selected output format, defaulting to text.
+func (ctx *Context) format() output.Format {
+	if ctx.Output == "" {
+		return output.FormatText
+	}
+	return ctx.Output
 }
 
 func (ctx *Context) NewClient(urlHint string) (*slack.Client, error) {
-	token, err := ctx.resolveToken(urlHint)
+	token, workspace, err := ctx.resolveToken(urlHint)
+	if err != nil {
+		return nil, err
+	}
+	ctx.workspaceKey = workspace
+
+	return ctx.newSlackClient(token)
+}
+
+// newSlackClient creates a client for token with the configured API URL and
+// HTTP transport.
+func (ctx *Context) newSlackClient(token string) (*slack.Client, error) {
+	opts, err := ctx.clientOptions()
 	if err != nil {
 		return nil, err
 	}
+	return slack.NewClient(token, opts...), nil
+}
+
+// clientOptions returns the options Slack clients are created with: the CLI's
+// user agent, plus the API base URL and CA bundle from SLACK_API_URL and
+// SLACK_CA_BUNDLE or the config file.
+func (ctx *Context) clientOptions() ([]slack.Option, error) {
+	if ctx.clientOpts != nil {
+		return ctx.clientOpts, nil
+	}
+
+	var apiURL, caBundle string
+	if ctx.Config != nil {
+		apiURL, caBundle = ctx.Config.APIURL, ctx.Config.CABundle
+	}
+	apiURL = cmp.Or(os.Getenv("SLACK_API_URL"), apiURL)
+	caBundle = cmp.Or(os.Getenv("SLACK_CA_BUNDLE"), caBundle)
+
+	opts := []slack.Option{slack.WithUserAgent("slack-cli/" + cmp.Or(ctx.Version, "dev"))}
+	if apiURL != "" {
+		if u, err := url.Parse(apiURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid API URL %q: expected something like https://slack.com/api", apiURL)
+		}
+		opts = append(opts, slack.WithBaseURL(apiURL))
+	}
+	if caBundle != "" {
+		httpClient, err := newHTTPClient(caBundle)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, slack.WithHTTPClient(httpClient))
+	}
 
-	return slack.NewClient(token), nil
+	ctx.clientOpts = opts
+	return opts, nil
 }
 
-func (ctx *Context) resolveToken(urlHint string) (string, error) {
+// newHTTPClient returns an HTTP client that trusts the certificate
+// authorities in the PEM file caBundle as well as the system's. Like the
+// default client, it honours HTTPS_PROXY.
+func newHTTPClient(caBundle string) (*http.Client, error) {
+	pem, err := os.ReadFile(caBundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates found in CA bundle %s", caBundle)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	return &http.Client{Transport: transport}, nil
+}
+
+// cacheRoot returns the on-disk cache shared by every workspace.
+func (ctx *Context) cacheRoot() (*cache.Cache, error) {
+	dir, err := cache.Dir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find cache directory: %w", err)
+	}
+	return cache.New(dir), nil
+}
+
+// cache returns the on-disk cache for the current workspace, or nil if
+// caching is disabled or there is no usable cache directory.
+func (ctx *Context) cache() *cache.Cache {
+	if ctx.NoCache || ctx.workspaceKey == "" {
+		return nil
+	}
+	root, err := ctx.cacheRoot()
+	if err != nil {
+		return nil
+	}
+	return root.Sub(ctx.workspaceKey)
+}
+
+// newResolver creates a Resolver backed by the workspace's on-disk cache.
+// Names it looks up are saved when the Context is closed.
+func (ctx *Context) newResolver(client *slack.Client) *slack.Resolver {
+	resolver := slack.NewCachedResolver(client, ctx.cache())
+	ctx.resolvers = append(ctx.resolvers, resolver)
+	return resolver
+}
+
+// Close saves anything the command's resolvers looked up to the on-disk
+// cache. Failing to save only costs lookups next time, so errors are ignored.
+func (ctx *Context) Close() {
+	for _, resolver := range ctx.resolvers {
+		_ = resolver.Save()
+	}
+	ctx.resolvers = nil
+}
+
+// resolveChannelID returns the ID for a conversation given by ID, name or
+// #name, using the workspace's cached channel list.
+func (ctx *Context) resolveChannelID(client *slack.Client, channel string) (string, error) {
+	return slack.NewChannelResolver(client, ctx.cache()).Resolve(channel)
+}
+
+func (ctx *Context) resolveToken(urlHint string) (string, string, error) {
 	workspaceHint := strings.TrimSpace(ctx.Workspace)
 	if urlHint != "" {
 		host, teamID, err := slack.ExtractWorkspaceRef(urlHint)
@@ -36,30 +175,45 @@ func (ctx *Context) resolveToken(urlHint string) (string, error) {
 		}
 	}
 
-	token, _, err := ctx.Config.TokenForWorkspace(workspaceHint)
+	token, workspace, err := ctx.Config.TokenForWorkspace(workspaceHint)
 	if err != nil {
 		if workspaceHint != "" && strings.TrimSpace(ctx.Workspace) == "" {
-			fallbackToken, _, fallbackErr := ctx.Config.TokenForWorkspace("")
+			fallbackToken, fallbackWorkspace, fallbackErr := ctx.Config.TokenForWorkspace("")
 			if fallbackErr == nil {
-				return fallbackToken, nil
+				return fallbackToken, fallbackWorkspace, nil
 			}
 		}
 
 		if workspaceHint != "" {
-			return "", fmt.Errorf("%w. Run 'slack-cli auth login' for that workspace or pass --workspace", err)
+			return "", "", fmt.Errorf("%w. Run 'slack-cli auth login' for that workspace or pass --workspace", err)
 		}
-		return "", err
+		return "", "", err
 	}
 
-	return token, nil
+	return token, workspace, nil
+}
+
+// printMoreHint tells the user that a listing stopped at --limit while Slack
+// still had more results. It writes to stderr so piped output stays clean.
+func printMoreHint(noun string) {
+	fmt.Fprintf(os.Stderr, "More %s available; increase --limit (0 for all) to see them.\n", noun)
 }
 
 type CLI struct {
 	Workspace string     `help:"Workspace host (e.g. buildkite.slack.com) or team ID" short:"w"`
+	Output    string     `help:"Output format: text, json, ndjson or markdown" enum:"text,json,ndjson,markdown" default:"text" short:"o"`
+	NoCache   bool       `help:"Don't read or write the on-disk cache of users and channels"`
 	Auth      AuthCmd    `cmd:"" help:"Authentication commands"`
+	Cache     CacheCmd   `cmd:"" help:"Manage the on-disk cache of users and channels"`
 	View      ViewCmd    `cmd:"" help:"View any Slack URL (message, thread, or channel)"`
 	Channel   ChannelCmd `cmd:"" help:"Channel commands"`
-	Search    SearchCmd  `cmd:"" help:"Search messages"`
+	Dm        DmCmd      `cmd:"" help:"Direct message commands"`
+	Emoji     EmojiCmd   `cmd:"" help:"Custom emoji commands"`
+	File      FileCmd    `cmd:"" help:"File commands"`
+	Listen    ListenCmd  `cmd:"" help:"Stream events as they happen, over Socket Mode"`
+	Message   MessageCmd `cmd:"" help:"Message commands"`
+	React     ReactCmd   `cmd:"" help:"Emoji reaction commands"`
+	Search    SearchCmd  `cmd:"" help:"Search messages and files"`
 	Thread    ThreadCmd  `cmd:"" help:"Thread commands"`
 	User      UserCmd    `cmd:"" help:"User commands"`
 	Version   VersionCmd `cmd:"" help:"Show version"`
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -17,7 +19,7 @@ func TestResolveTokenFallsBackToDefaultForUnmappedURLWorkspace(t *testing.T) {
 		},
 	}
 
-	token, err := ctx.resolveToken("https://buildkite.slack.com/archives/C123/p1234567890123456")
+	token, _, err := ctx.resolveToken("https://buildkite.slack.com/archives/C123/p1234567890123456")
 	if err != nil {
 		t.Fatalf("resolveToken returned error: %v", err)
 	}
@@ -37,7 +39,7 @@ func TestResolveTokenExplicitWorkspaceStillErrors(t *testing.T) {
 		},
 	}
 
-	_, err := ctx.resolveToken("")
+	_, _, err := ctx.resolveToken("")
 	if err == nil {
 		t.Fatalf("expected error for unknown explicit workspace")
 	}
@@ -45,3 +47,37 @@ func TestResolveTokenExplicitWorkspaceStillErrors(t *testing.T) {
 		t.Fatalf("expected slack-cli auth hint, got %q", err.Error())
 	}
 }
+
+func TestClientOptionsPreferEnvironment(t *testing.T) {
+	t.Setenv("SLACK_API_URL", "not a url")
+	t.Setenv("SLACK_CA_BUNDLE", "")
+
+	ctx := &Context{Config: &config.Config{APIURL: "http://localhost:9999/api"}}
+	if _, err := ctx.clientOptions(); err == nil || !strings.Contains(err.Error(), "invalid API URL") {
+		t.Fatalf("expected SLACK_API_URL to override the config file and be rejected, got %v", err)
+	}
+
+	t.Setenv("SLACK_API_URL", "")
+	ctx = &Context{Config: &config.Config{APIURL: "http://localhost:9999/api"}}
+	opts, err := ctx.clientOptions()
+	if err != nil {
+		t.Fatalf("clientOptions returned error: %v", err)
+	}
+	if len(opts) != 2 {
+		t.Fatalf("expected user agent and base URL options, got %d", len(opts))
+	}
+}
+
+func TestNewHTTPClientRejectsBundleWithoutCertificates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := newHTTPClient(path); err == nil || !strings.Contains(err.Error(), "no certificates") {
+		t.Fatalf("expected an error for a bundle without certificates, got %v", err)
+	}
+	if _, err := newHTTPClient(filepath.Join(t.TempDir(), "missing.pem")); err == nil {
+		t.Fatal("expected an error for a missing bundle")
+	}
+}
@@ -2,45 +2,325 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/lox/slack-cli/internal/output"
 	"github.com/lox/slack-cli/internal/slack"
+	"github.com/lox/slack-cli/internal/timeparse"
+	"golang.org/x/term"
 )
 
+// maxSearchPages is the deepest page the search methods will return.
+const maxSearchPages = 100
+
+// Slack wraps highlighted search terms in these private-use characters.
+const (
+	highlightStart = "\ue000"
+	highlightEnd   = "\ue001"
+)
+
+var channelIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{6,}$`)
+
 type SearchCmd struct {
-	Query string `arg:"" help:"Search query (supports Slack search syntax: from:@user, in:#channel, etc.)"`
-	Limit int    `help:"Maximum number of results" default:"20"`
+	Messages SearchMessagesCmd `cmd:"" default:"withargs" help:"Search messages (the default)"`
+	Files    SearchFilesCmd    `cmd:"" help:"Search files"`
+	All      SearchAllCmd      `cmd:"" help:"Search messages and files together"`
+}
+
+// SearchFlags are the query, paging, ordering and filter flags shared by the
+// search commands.
+type SearchFlags struct {
+	Query  string   `arg:"" optional:"" help:"Search query (supports Slack search syntax: from:@user, in:#channel, etc.)"`
+	Limit  int      `help:"Results per page (up to 100)" default:"20"`
+	Page   int      `help:"Page of results to show" default:"1"`
+	All    bool     `help:"Fetch every page of results (Slack stops at page 100)"`
+	Sort   string   `help:"Order results by relevance score or timestamp" enum:"score,timestamp" default:"score"`
+	Asc    bool     `help:"Sort ascending (oldest or least relevant first)"`
+	From   []string `help:"Only results from these users (@name or user ID)" placeholder:"USER"`
+	In     []string `help:"Only results in these conversations (#channel, @user or ID)" placeholder:"CHANNEL"`
+	After  string   `help:"Only results after this day (2024-05-01, yesterday, 7d, 'last monday')"`
+	Before string   `help:"Only results before this day (same formats as --after)"`
+}
+
+func (f SearchFlags) validate() error {
+	if f.Limit < 1 || f.Limit > 100 {
+		return fmt.Errorf("--limit must be between 1 and 100")
+	}
+	if f.Page < 1 || f.Page > maxSearchPages {
+		return fmt.Errorf("--page must be between 1 and %d", maxSearchPages)
+	}
+	return nil
+}
+
+// modifiers returns the free-text query followed by the filter flags as
+// Slack search modifiers.
+func (f SearchFlags) modifiers(now time.Time) ([]string, error) {
+	var parts []string
+	if q := strings.TrimSpace(f.Query); q != "" {
+		parts = append(parts, q)
+	}
+	for _, user := range f.From {
+		parts = append(parts, "from:"+searchUser(user))
+	}
+	for _, channel := range f.In {
+		parts = append(parts, "in:"+searchChannel(channel))
+	}
+	if f.After != "" {
+		day, err := searchDay(f.After, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --after: %w", err)
+		}
+		parts = append(parts, "after:"+day)
+	}
+	if f.Before != "" {
+		day, err := searchDay(f.Before, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --before: %w", err)
+		}
+		parts = append(parts, "before:"+day)
+	}
+	return parts, nil
+}
+
+// fetch calls page for the requested page of results, or with --all for
+// every page until it reports there are no more.
+func (f SearchFlags) fetch(highlight bool, page func(opts slack.SearchOptions) (more bool, err error)) error {
+	opts := slack.SearchOptions{
+		Count:     f.Limit,
+		Page:      f.Page,
+		Sort:      f.Sort,
+		Asc:       f.Asc,
+		Highlight: highlight,
+	}
+	if f.All {
+		opts.Count, opts.Page = 100, 1
+	}
+
+	for {
+		more, err := page(opts)
+		if err != nil {
+			return fmt.Errorf("search failed: %w", err)
+		}
+		if !f.All || !more {
+			return nil
+		}
+		opts.Page++
+	}
+}
+
+// searchResults is one or more pages of results from a search method.
+type searchResults[T any] struct {
+	Total int
+	// FirstPage and Page are the first and last pages fetched.
+	FirstPage int
+	Page      int
+	Pages     int
+	PerPage   int
+	Matches   []T
+}
+
+// add records a page of results, reporting whether there are more to fetch.
+func (r *searchResults[T]) add(total int, paging slack.SearchPaging, opts slack.SearchOptions, matches []T) bool {
+	r.Total = total
+	r.Page = max(paging.Page, opts.Page)
+	r.Pages = paging.Pages
+	r.PerPage = opts.Count
+	r.Matches = append(r.Matches, matches...)
+	if r.FirstPage == 0 {
+		r.FirstPage = r.Page
+	}
+	return r.hasMore() && len(matches) > 0
+}
+
+func (r searchResults[T]) hasMore() bool {
+	return r.Page < min(r.Pages, maxSearchPages)
+}
+
+// pageSummary describes which pages of a multi-page result were fetched.
+func (r searchResults[T]) pageSummary() string {
+	switch {
+	case r.Pages <= 1:
+		return ""
+	case r.FirstPage == r.Page:
+		return fmt.Sprintf(" (page %d of %d)", r.Page, r.Pages)
+	}
+	return fmt.Sprintf(" (pages %d-%d of %d)", r.FirstPage, r.Page, r.Pages)
+}
+
+func (r searchResults[T]) pagingJSON() searchPagingJSON {
+	return searchPagingJSON{
+		Total:   r.Total,
+		Page:    r.Page,
+		Pages:   r.Pages,
+		PerPage: r.PerPage,
+		HasMore: r.hasMore(),
+	}
+}
+
+// printMoreResultsHint points at the next page when there is one.
+func printMoreResultsHint(page int, more bool) {
+	if more {
+		fmt.Fprintf(os.Stderr, "More results available; use --page %d or --all to see them.\n", page+1)
+	}
+}
+
+type SearchMessagesCmd struct {
+	Flags SearchFlags `embed:""`
+
+	Highlight  bool     `help:"Highlight the matched terms (always on for terminal output)"`
+	Context    int      `help:"Show this many messages before and after each match" default:"0" placeholder:"N"`
+	WithThread bool     `help:"Show the whole thread for matches in threads"`
+	Has        []string `help:"Only messages with a link, reaction, pin or :emoji: reaction" placeholder:"link|reaction|pin|:emoji:"`
+	Is         []string `help:"Only messages that are thread replies" placeholder:"thread"`
 }
 
-func (c *SearchCmd) Run(ctx *Context) error {
+func (c *SearchMessagesCmd) Run(ctx *Context) error {
+	query, err := c.searchQuery(time.Now())
+	if err != nil {
+		return err
+	}
+	if err := c.Flags.validate(); err != nil {
+		return err
+	}
+	if c.Context < 0 {
+		return fmt.Errorf("--context can't be negative")
+	}
+
+	format := ctx.format()
+	// Matched terms are always highlighted on a terminal.
+	bold := format == output.FormatText && term.IsTerminal(int(os.Stdout.Fd()))
+
 	client, err := ctx.NewClient("")
 	if err != nil {
 		return err
 	}
-	resolver := slack.NewResolver(client)
-	resp, err := client.SearchMessages(c.Query, c.Limit)
+	resolver := ctx.newResolver(client)
+
+	var results searchResults[slack.SearchMatch]
+	err = c.Flags.fetch(c.Highlight || bold, func(opts slack.SearchOptions) (bool, error) {
+		resp, err := client.SearchMessages(query, opts)
+		if err != nil {
+			return false, err
+		}
+		return results.add(resp.Messages.Total, resp.Messages.Paging, opts, resp.Messages.Matches), nil
+	})
 	if err != nil {
-		return fmt.Errorf("search failed: %w", err)
+		return err
+	}
+	hits := c.expandMatches(client, results.Matches)
+	prefetchHits(resolver, hits)
+
+	switch {
+	case format.Structured():
+		doc := newSearchJSON(resolver, query, results, hits)
+		return writeStructured(format, doc, doc.Matches)
+	case format == output.FormatMarkdown:
+		formatter := &messageFormatter{resolver: resolver}
+		fmt.Print(searchMarkdown(formatter, results, hits))
+		return nil
 	}
 
-	if resp.Messages.Total == 0 {
+	if results.Total == 0 {
 		fmt.Println("No messages found.")
 		return nil
 	}
 
-	fmt.Printf("Found %d messages:\n\n", resp.Messages.Total)
+	fmt.Printf("Found %d messages%s:\n\n", results.Total, results.pageSummary())
+	printHits(resolver, hits, c.Context > 0 || c.WithThread, bold)
+	printMoreResultsHint(results.Page, results.hasMore())
 
-	for _, match := range resp.Messages.Matches {
-		channel := match.Channel.Name
-		if channel == "" {
-			channel = match.Channel.ID
+	return nil
+}
+
+// searchQuery combines the free-text query with the filter flags as Slack
+// search modifiers.
+func (c *SearchMessagesCmd) searchQuery(now time.Time) (string, error) {
+	parts, err := c.Flags.modifiers(now)
+	if err != nil {
+		return "", err
+	}
+	for _, has := range c.Has {
+		switch {
+		case has == "link", has == "reaction", has == "pin":
+		case len(has) > 2 && strings.HasPrefix(has, ":") && strings.HasSuffix(has, ":"):
+		default:
+			return "", fmt.Errorf("invalid --has %q: expected link, reaction, pin or :emoji:", has)
 		}
-		fmt.Printf("#%s [%s]\n", channel, match.TS)
-		fmt.Printf("  %s: %s\n", match.Username, resolver.FormatText(match.Text))
-		if match.Permalink != "" {
-			fmt.Printf("  %s\n", match.Permalink)
+		parts = append(parts, "has:"+has)
+	}
+	for _, is := range c.Is {
+		if is != "thread" {
+			return "", fmt.Errorf("invalid --is %q: expected thread", is)
 		}
-		fmt.Println()
+		parts = append(parts, "is:"+is)
 	}
+	return joinSearchQuery(parts)
+}
 
-	return nil
+// joinSearchQuery joins a query's terms and modifiers, requiring at least one.
+func joinSearchQuery(parts []string) (string, error) {
+	if len(parts) == 0 {
+		return "", fmt.Errorf("a search query or at least one filter flag is required")
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// searchUser formats a user for a from: modifier. IDs use Slack's mention
+// syntax so they match regardless of the user's current name.
+func searchUser(user string) string {
+	if userIDPattern.MatchString(user) {
+		return "<@" + user + ">"
+	}
+	return "@" + strings.TrimPrefix(user, "@")
+}
+
+// searchChannel formats a conversation for an in: modifier. Names are
+// channels unless they start with @, which searches a DM.
+func searchChannel(channel string) string {
+	switch {
+	case channelIDPattern.MatchString(channel):
+		return "<#" + channel + ">"
+	case strings.HasPrefix(channel, "@"):
+		return searchUser(strings.TrimPrefix(channel, "@"))
+	}
+	return "#" + strings.TrimPrefix(channel, "#")
+}
+
+// searchDay parses a time expression into the YYYY-MM-DD day Slack's
+// after: and before: modifiers expect.
+func searchDay(s string, now time.Time) (string, error) {
+	t, err := timeparse.Parse(s, now)
+	if err != nil {
+		return "", err
+	}
+	return t.Format("2006-01-02"), nil
+}
+
+func replaceHighlights(s, start, end string) string {
+	return strings.NewReplacer(highlightStart, start, highlightEnd, end).Replace(s)
+}
+
+func stripHighlights(s string) string {
+	return replaceHighlights(s, "", "")
+}
+
+func searchMarkdown(formatter *messageFormatter, results searchResults[slack.SearchMatch], hits []searchHit) string {
+	header := fmt.Sprintf("# Found %d messages%s\n\n", results.Total, results.pageSummary())
+	return header + searchHitsMarkdown(formatter, hits, "##")
+}
+
+// searchHitsMarkdown renders hits under a heading of the given level for
+// each conversation, with the matched terms in bold.
+func searchHitsMarkdown(formatter *messageFormatter, hits []searchHit, heading string) string {
+	var sb strings.Builder
+	for _, group := range groupByChannel(hits) {
+		fmt.Fprintf(&sb, "%s #%s\n\n", heading, group.name())
+		for _, hit := range group.Hits {
+			writeSearchHit(&sb, formatter, hit)
+		}
+	}
+	return replaceHighlights(sb.String(), "**", "**")
 }
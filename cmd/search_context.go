@@ -0,0 +1,299 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+// searchThreadLimit caps the replies fetched for a match's thread.
+const searchThreadLimit = 200
+
+// searchHit is a search match with the messages fetched around it.
+type searchHit struct {
+	Match slack.SearchMatch
+	// ThreadTS is the thread the match was posted in, empty for top-level
+	// messages.
+	ThreadTS string
+	// Before and After are the neighbouring messages, oldest first. For a
+	// reply they come from its thread, unless the whole thread is shown.
+	Before []slack.Message
+	After  []slack.Message
+	// Thread is the match's thread, parent first, with --with-thread.
+	Thread []slack.Message
+}
+
+// matchMessage returns the matched message as found in the fetched
+// messages, with Slack's highlighted search text in place of its own.
+func (h searchHit) matchMessage() slack.Message {
+	msg := slack.Message{User: h.Match.User, TS: h.Match.TS, ThreadTS: h.ThreadTS}
+	for _, m := range h.Thread {
+		if m.TS == h.Match.TS {
+			msg = m
+		}
+	}
+	msg.Text, msg.Blocks = h.Match.Text, nil
+	return msg
+}
+
+// author names a message's poster, using the name search reports for bot
+// matches that have no user ID.
+func (h searchHit) author(resolver *slack.Resolver, msg slack.Message) string {
+	if msg.User == "" && msg.TS == h.Match.TS && h.Match.Username != "" {
+		return h.Match.Username
+	}
+	return resolver.ResolveUser(msg.User)
+}
+
+// thread returns the hit's thread with the match highlighted.
+func (h searchHit) thread() []slack.Message {
+	thread := make([]slack.Message, len(h.Thread))
+	for i, msg := range h.Thread {
+		if msg.TS == h.Match.TS {
+			msg = h.matchMessage()
+		}
+		thread[i] = msg
+	}
+	return thread
+}
+
+// messages returns every message the hit shows, for prefetching.
+func (h searchHit) messages() []slack.Message {
+	messages := append([]slack.Message{h.matchMessage()}, h.Before...)
+	messages = append(messages, h.After...)
+	return append(messages, h.Thread...)
+}
+
+// searchGroup is the hits from one conversation, in result order.
+type searchGroup struct {
+	Channel slack.SearchChannel
+	Hits    []searchHit
+}
+
+func (g searchGroup) name() string {
+	return cmp.Or(g.Channel.Name, g.Channel.ID)
+}
+
+// groupByChannel groups hits by conversation, ordering the groups by their
+// first hit.
+func groupByChannel(hits []searchHit) []searchGroup {
+	var groups []searchGroup
+	index := make(map[string]int)
+	for _, hit := range hits {
+		i, ok := index[hit.Match.Channel.ID]
+		if !ok {
+			i = len(groups)
+			index[hit.Match.Channel.ID] = i
+			groups = append(groups, searchGroup{Channel: hit.Match.Channel})
+		}
+		groups[i].Hits = append(groups[i].Hits, hit)
+	}
+	return groups
+}
+
+// matchThreadTS returns the thread a match was posted in, which search only
+// reports through the permalink.
+func matchThreadTS(match slack.SearchMatch) string {
+	u, err := url.Parse(match.Permalink)
+	if err != nil {
+		return ""
+	}
+	if threadTS := u.Query().Get("thread_ts"); threadTS != match.TS {
+		return threadTS
+	}
+	return ""
+}
+
+// expandMatches fetches the context and threads requested for each match.
+// Conversations that can't be read are skipped with a warning rather than
+// failing the whole search.
+func (c *SearchMessagesCmd) expandMatches(client *slack.Client, matches []slack.SearchMatch) []searchHit {
+	hits := make([]searchHit, 0, len(matches))
+	for _, match := range matches {
+		hit := searchHit{Match: match, ThreadTS: matchThreadTS(match)}
+		if err := c.expand(client, &hit); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: couldn't fetch context for the match in #%s: %v\n", cmp.Or(match.Channel.Name, match.Channel.ID), err)
+		}
+		hits = append(hits, hit)
+	}
+	return hits
+}
+
+func (c *SearchMessagesCmd) expand(client *slack.Client, hit *searchHit) error {
+	channelID := hit.Match.Channel.ID
+
+	var thread []slack.Message
+	if c.WithThread || (c.Context > 0 && hit.ThreadTS != "") {
+		replies, err := client.GetConversationReplies(channelID, cmp.Or(hit.ThreadTS, hit.Match.TS), slack.HistoryOptions{Limit: searchThreadLimit})
+		if err != nil {
+			return err
+		}
+		thread = replies.Messages
+	}
+	if c.WithThread && len(thread) > 1 {
+		hit.Thread = thread
+	}
+	if c.Context == 0 {
+		return nil
+	}
+
+	// Replies aren't in the channel history, so without the whole thread
+	// their context is the replies around them.
+	if hit.ThreadTS != "" && hit.Thread == nil {
+		hit.Before = neighbours(thread, hit.Match.TS, c.Context, true)
+		hit.After = neighbours(thread, hit.Match.TS, c.Context, false)
+		return nil
+	}
+
+	anchor := cmp.Or(hit.ThreadTS, hit.Match.TS)
+	before, err := client.GetConversationHistory(channelID, slack.HistoryOptions{Limit: c.Context, Latest: anchor})
+	if err != nil {
+		return err
+	}
+	after, err := messagesAfter(client, channelID, anchor, c.Context)
+	if err != nil {
+		return err
+	}
+	hit.Before = neighbours(before.Messages, anchor, c.Context, true)
+	hit.After = neighbours(after, anchor, c.Context, false)
+	return nil
+}
+
+// afterContextWindow is the first span of history searched for the messages
+// after a match.
+const afterContextWindow = time.Hour
+
+// messagesAfter returns at least n of the messages posted after ts, if there
+// are that many. History comes newest first, so asking for n messages after
+// ts would return the channel's latest ones. Instead it fetches the whole of
+// a window after ts, doubling the window until it holds n messages or
+// reaches the present.
+func messagesAfter(client *slack.Client, channelID, ts string, n int) ([]slack.Message, error) {
+	start, err := slack.ParseTS(ts)
+	if err != nil {
+		return nil, err
+	}
+
+	for window := afterContextWindow; ; window *= 2 {
+		end := start.Add(window)
+		opts := slack.HistoryOptions{Oldest: ts}
+		if end.Before(time.Now()) {
+			opts.Latest = slack.FormatTS(end)
+		}
+		history, err := client.GetConversationHistory(channelID, opts)
+		if err != nil {
+			return nil, err
+		}
+		if len(history.Messages) >= n || opts.Latest == "" {
+			return history.Messages, nil
+		}
+	}
+}
+
+// neighbours returns up to n of the messages immediately before or after ts,
+// oldest first.
+func neighbours(messages []slack.Message, ts string, n int, before bool) []slack.Message {
+	var out []slack.Message
+	for _, msg := range messages {
+		if before && msg.TS < ts || !before && msg.TS > ts {
+			out = append(out, msg)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TS < out[j].TS })
+	if len(out) > n {
+		if before {
+			return out[len(out)-n:]
+		}
+		return out[:n]
+	}
+	return out
+}
+
+// prefetchHits resolves the users and channels in every message the hits
+// show.
+func prefetchHits(resolver *slack.Resolver, hits []searchHit) {
+	var messages []slack.Message
+	for _, hit := range hits {
+		messages = append(messages, hit.messages()...)
+	}
+	resolver.Prefetch(messages)
+}
+
+// printHits prints hits grouped by conversation, marking the matches with ">"
+// if marked is set. bold highlights the matched terms for a terminal.
+func printHits(resolver *slack.Resolver, hits []searchHit, marked, bold bool) {
+	mark := "  "
+	if marked {
+		mark = "> "
+	}
+	line := func(hit searchHit, prefix string, msg slack.Message) {
+		text := resolver.FormatMessage(msg)
+		if bold {
+			text = replaceHighlights(text, "\x1b[1m", "\x1b[0m")
+		} else {
+			text = stripHighlights(text)
+		}
+		fmt.Printf("%s[%s] %s: %s\n", prefix, msg.TS, hit.author(resolver, msg), text)
+	}
+
+	for _, group := range groupByChannel(hits) {
+		fmt.Printf("#%s\n\n", group.name())
+		for _, hit := range group.Hits {
+			for _, msg := range hit.Before {
+				line(hit, "    ", msg)
+			}
+			if hit.Thread != nil {
+				for i, msg := range hit.thread() {
+					prefix := "    "
+					if msg.TS == hit.Match.TS {
+						prefix = "  " + mark
+					}
+					if i > 0 {
+						prefix = "  " + prefix
+					}
+					line(hit, prefix, msg)
+				}
+			} else {
+				line(hit, "  "+mark, hit.matchMessage())
+			}
+			for _, msg := range hit.After {
+				line(hit, "    ", msg)
+			}
+			if hit.Match.Permalink != "" {
+				fmt.Printf("    %s\n", hit.Match.Permalink)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// writeSearchHit writes a hit and its context as markdown, leaving the
+// highlight markers for the caller to replace.
+func writeSearchHit(sb *strings.Builder, formatter *messageFormatter, hit searchHit) {
+	write := func(msg slack.Message) {
+		user := hit.author(formatter.resolver, msg)
+		fmt.Fprintf(sb, "**%s** _%s_\n\n%s\n\n", user, formatTimestamp(msg.TS), formatter.text(msg))
+	}
+
+	for _, msg := range hit.Before {
+		write(msg)
+	}
+	if hit.Thread != nil {
+		formatter.writeThread(sb, hit.thread())
+	} else {
+		write(hit.matchMessage())
+	}
+	for _, msg := range hit.After {
+		write(msg)
+	}
+	if hit.Match.Permalink != "" {
+		fmt.Fprintf(sb, "[View in Slack](%s)\n\n", hit.Match.Permalink)
+	}
+	sb.WriteString("---\n\n")
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lox/slack-cli/internal/output"
+	"github.com/lox/slack-cli/internal/slack"
+	"golang.org/x/term"
+)
+
+type SearchFilesCmd struct {
+	Flags SearchFlags `embed:""`
+
+	Type     []string `help:"Only files of these types: pdf, images, snippets, spreadsheets, presentations, gdocs, zips or a file extension" placeholder:"TYPE"`
+	Download string   `help:"Download the matching files into this directory" placeholder:"DIR" type:"path"`
+}
+
+func (c *SearchFilesCmd) Run(ctx *Context) error {
+	parts, err := c.Flags.modifiers(time.Now())
+	if err != nil {
+		return err
+	}
+	for _, t := range c.Type {
+		parts = append(parts, "type:"+strings.ToLower(strings.TrimPrefix(t, ".")))
+	}
+	query, err := joinSearchQuery(parts)
+	if err != nil {
+		return err
+	}
+	if err := c.Flags.validate(); err != nil {
+		return err
+	}
+
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+	resolver := ctx.newResolver(client)
+
+	var results searchResults[slack.File]
+	err = c.Flags.fetch(false, func(opts slack.SearchOptions) (bool, error) {
+		resp, err := client.SearchFiles(query, opts)
+		if err != nil {
+			return false, err
+		}
+		return results.add(resp.Files.Total, resp.Files.Paging, opts, resp.Files.Matches), nil
+	})
+	if err != nil {
+		return err
+	}
+	resolver.PrefetchFiles(results.Matches)
+
+	switch format := ctx.format(); {
+	case format.Structured():
+		doc := newSearchFilesJSON(resolver, query, results)
+		if err := writeStructured(format, doc, doc.Files); err != nil {
+			return err
+		}
+	case format == output.FormatMarkdown:
+		fmt.Printf("# Found %d files%s\n\n", results.Total, results.pageSummary())
+		fmt.Print(searchFilesMarkdown(resolver, results.Matches))
+	case results.Total == 0:
+		fmt.Println("No files found.")
+	default:
+		fmt.Printf("Found %d files%s:\n\n", results.Total, results.pageSummary())
+		printFiles(resolver, results.Matches)
+	}
+	printMoreResultsHint(results.Page, results.hasMore())
+
+	if c.Download != "" && len(results.Matches) > 0 {
+		if _, err := downloadFiles(client, results.Matches, c.Download); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type SearchAllCmd struct {
+	Flags SearchFlags `embed:""`
+}
+
+func (c *SearchAllCmd) Run(ctx *Context) error {
+	parts, err := c.Flags.modifiers(time.Now())
+	if err != nil {
+		return err
+	}
+	query, err := joinSearchQuery(parts)
+	if err != nil {
+		return err
+	}
+	if err := c.Flags.validate(); err != nil {
+		return err
+	}
+
+	format := ctx.format()
+	bold := format == output.FormatText && term.IsTerminal(int(os.Stdout.Fd()))
+
+	client, err := ctx.NewClient("")
+	if err != nil {
+		return err
+	}
+	resolver := ctx.newResolver(client)
+
+	var messages searchResults[slack.SearchMatch]
+	var files searchResults[slack.File]
+	err = c.Flags.fetch(bold, func(opts slack.SearchOptions) (bool, error) {
+		resp, err := client.SearchAll(query, opts)
+		if err != nil {
+			return false, err
+		}
+		moreMessages := messages.add(resp.Messages.Total, resp.Messages.Paging, opts, resp.Messages.Matches)
+		moreFiles := files.add(resp.Files.Total, resp.Files.Paging, opts, resp.Files.Matches)
+		return moreMessages || moreFiles, nil
+	})
+	if err != nil {
+		return err
+	}
+	hits := make([]searchHit, 0, len(messages.Matches))
+	for _, match := range messages.Matches {
+		hits = append(hits, searchHit{Match: match, ThreadTS: matchThreadTS(match)})
+	}
+	prefetchHits(resolver, hits)
+	resolver.PrefetchFiles(files.Matches)
+
+	// The larger result set decides how many pages there are.
+	pages := messages.pageSummary()
+	if files.Pages > messages.Pages {
+		pages = files.pageSummary()
+	}
+	more := messages.hasMore() || files.hasMore()
+	page := max(messages.Page, files.Page)
+
+	switch {
+	case format.Structured():
+		doc := searchAllJSON{
+			Query:    query,
+			Messages: newSearchJSON(resolver, "", messages, hits),
+			Files:    newSearchFilesJSON(resolver, "", files),
+		}
+		if err := writeStructured(format, doc, doc.items()); err != nil {
+			return err
+		}
+	case format == output.FormatMarkdown:
+		formatter := &messageFormatter{resolver: resolver}
+		fmt.Printf("# Found %d messages and %d files%s\n\n", messages.Total, files.Total, pages)
+		if len(hits) > 0 {
+			fmt.Print("## Messages\n\n" + searchHitsMarkdown(formatter, hits, "###"))
+		}
+		if len(files.Matches) > 0 {
+			fmt.Print("## Files\n\n" + searchFilesMarkdown(resolver, files.Matches))
+		}
+	case messages.Total == 0 && files.Total == 0:
+		fmt.Println("No messages or files found.")
+	default:
+		fmt.Printf("Found %d messages and %d files%s:\n\n", messages.Total, files.Total, pages)
+		if len(hits) > 0 {
+			fmt.Print("Messages:\n\n")
+			printHits(resolver, hits, false, bold)
+		}
+		if len(files.Matches) > 0 {
+			fmt.Print("Files:\n\n")
+			printFiles(resolver, files.Matches)
+		}
+	}
+	printMoreResultsHint(page, more)
+
+	return nil
+}
+
+// items lists the message and then file matches for NDJSON output, each
+// tagged with its type.
+func (doc searchAllJSON) items() []any {
+	items := make([]any, 0, len(doc.Messages.Matches)+len(doc.Files.Files))
+	for _, m := range doc.Messages.Matches {
+		items = append(items, searchAllMessageJSON{Type: "message", searchMatchJSON: m})
+	}
+	for _, f := range doc.Files.Files {
+		items = append(items, searchAllFileJSON{Type: "file", searchFileJSON: f})
+	}
+	return items
+}
+
+// fileOrigin describes who shared a file, where and when, e.g.
+// "alice in #ops · Jan 2, 3:04 PM".
+func fileOrigin(resolver *slack.Resolver, file slack.File) string {
+	var parts []string
+	if file.User != "" {
+		parts = append(parts, resolver.ResolveUser(file.User))
+	}
+	var places []string
+	for _, id := range file.Conversations() {
+		if strings.HasPrefix(id, "D") {
+			places = append(places, "a DM")
+			continue
+		}
+		places = append(places, "#"+resolver.ResolveChannel(id))
+	}
+	if len(places) > 0 {
+		parts = append(parts, "in "+strings.Join(places, ", "))
+	}
+	origin := strings.Join(parts, " ")
+	if file.Created > 0 {
+		created := formatTimestamp(slack.FormatTS(time.Unix(file.Created, 0)))
+		if origin == "" {
+			return created
+		}
+		origin += " · " + created
+	}
+	return origin
+}
+
+// printFiles prints file search results for the text output format.
+func printFiles(resolver *slack.Resolver, files []slack.File) {
+	for _, file := range files {
+		fmt.Printf("%s (%s)\n", fileName(file), fileDetails(file))
+		if origin := fileOrigin(resolver, file); origin != "" {
+			fmt.Printf("  %s\n", origin)
+		}
+		if file.Permalink != "" {
+			fmt.Printf("  %s\n", file.Permalink)
+		}
+		fmt.Println()
+	}
+}
+
+func searchFilesMarkdown(resolver *slack.Resolver, files []slack.File) string {
+	var sb strings.Builder
+	for _, file := range files {
+		fmt.Fprintf(&sb, "- 📎 %s · %s", markdownFileLink(file), fileDetails(file))
+		if origin := fileOrigin(resolver, file); origin != "" {
+			fmt.Fprintf(&sb, " · %s", slack.EscapeMarkdown(origin))
+		}
+		sb.WriteString("\n")
+	}
+	if len(files) > 0 {
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
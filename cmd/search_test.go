@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestSearchQuery(t *testing.T) {
+	now := time.Date(2024, 5, 15, 12, 0, 0, 0, time.UTC)
+	cmd := SearchMessagesCmd{
+		Flags: SearchFlags{
+			Query:  "deploy failed",
+			From:   []string{"@alice", "U0123ABCD"},
+			In:     []string{"#ops", "C0123ABCD", "@bob"},
+			After:  "2024-05-01",
+			Before: "yesterday",
+		},
+		Has: []string{"link", ":eyes:"},
+		Is:  []string{"thread"},
+	}
+
+	got, err := cmd.searchQuery(now)
+	if err != nil {
+		t.Fatalf("searchQuery returned error: %v", err)
+	}
+	want := "deploy failed from:@alice from:<@U0123ABCD> in:#ops in:<#C0123ABCD> in:@bob " +
+		"after:2024-05-01 before:2024-05-14 has:link has::eyes: is:thread"
+	if got != want {
+		t.Fatalf("searchQuery() =\n  %q\nwant\n  %q", got, want)
+	}
+}
+
+func TestSearchQueryErrors(t *testing.T) {
+	now := time.Now()
+	query := SearchFlags{Query: "x"}
+	tests := map[string]SearchMessagesCmd{
+		"empty":      {},
+		"bad has":    {Flags: query, Has: []string{"file"}},
+		"bad is":     {Flags: query, Is: []string{"saved"}},
+		"bad after":  {Flags: SearchFlags{Query: "x", After: "whenever"}},
+		"bad before": {Flags: SearchFlags{Query: "x", Before: "whenever"}},
+	}
+	for name, cmd := range tests {
+		if _, err := cmd.searchQuery(now); err == nil {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPageSummary(t *testing.T) {
+	tests := []struct {
+		results searchResults[slack.SearchMatch]
+		want    string
+	}{
+		{searchResults[slack.SearchMatch]{FirstPage: 1, Page: 1, Pages: 1}, ""},
+		{searchResults[slack.SearchMatch]{FirstPage: 2, Page: 2, Pages: 5}, " (page 2 of 5)"},
+		{searchResults[slack.SearchMatch]{FirstPage: 1, Page: 5, Pages: 5}, " (pages 1-5 of 5)"},
+	}
+	for _, tt := range tests {
+		if got := tt.results.pageSummary(); got != tt.want {
+			t.Fatalf("pageSummary(%+v) = %q, want %q", tt.results, got, tt.want)
+		}
+	}
+}
+
+func TestSearchResultsAdd(t *testing.T) {
+	var results searchResults[slack.File]
+	opts := slack.SearchOptions{Count: 2, Page: 1}
+	if !results.add(5, slack.SearchPaging{Page: 1, Pages: 3}, opts, []slack.File{{ID: "F1"}, {ID: "F2"}}) {
+		t.Fatal("expected more pages after page 1 of 3")
+	}
+	opts.Page = 3
+	if results.add(5, slack.SearchPaging{Page: 3, Pages: 3}, opts, []slack.File{{ID: "F5"}}) {
+		t.Fatal("expected no more pages after page 3 of 3")
+	}
+	if results.FirstPage != 1 || results.Page != 3 || len(results.Matches) != 3 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestReplaceHighlights(t *testing.T) {
+	text := "the \ue000deploy\ue001 failed"
+	if got := replaceHighlights(text, "**", "**"); got != "the **deploy** failed" {
+		t.Fatalf("unexpected markdown highlight: %q", got)
+	}
+	if got := stripHighlights(text); got != "the deploy failed" {
+		t.Fatalf("unexpected stripped text: %q", got)
+	}
+}
+
+func TestNeighbours(t *testing.T) {
+	messages := []slack.Message{{TS: "5.0"}, {TS: "4.0"}, {TS: "3.0"}, {TS: "2.0"}, {TS: "1.0"}}
+
+	if got := timestamps(neighbours(messages, "3.0", 1, true)); !reflect.DeepEqual(got, []string{"2.0"}) {
+		t.Fatalf("unexpected messages before: %v", got)
+	}
+	if got := timestamps(neighbours(messages, "3.0", 5, true)); !reflect.DeepEqual(got, []string{"1.0", "2.0"}) {
+		t.Fatalf("unexpected messages before: %v", got)
+	}
+	if got := timestamps(neighbours(messages, "3.0", 1, false)); !reflect.DeepEqual(got, []string{"4.0"}) {
+		t.Fatalf("unexpected messages after: %v", got)
+	}
+}
+
+func TestExpandFetchesMessagesRightAfterTheMatch(t *testing.T) {
+	history := []slack.Message{
+		{TS: "1699999000.000000"},
+		{TS: "1700000000.000100"}, // the match
+		{TS: "1700000600.000000"},
+		{TS: "1700010800.000000"},
+		{TS: "1700018000.000000"},
+		{TS: "1750000000.000000"},
+		{TS: "1750000100.000000"},
+	}
+	// Like Slack, return the newest messages in range first.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		oldest, latest := r.Form.Get("oldest"), r.Form.Get("latest")
+		var page []slack.Message
+		for _, msg := range history {
+			if (oldest == "" || msg.TS > oldest) && (latest == "" || msg.TS < latest) {
+				page = append(page, msg)
+			}
+		}
+		sort.Slice(page, func(i, j int) bool { return page[i].TS > page[j].TS })
+		if limit, _ := strconv.Atoi(r.Form.Get("limit")); limit > 0 && len(page) > limit {
+			page = page[:limit]
+		}
+		_ = json.NewEncoder(w).Encode(slack.HistoryResponse{OK: true, Messages: page})
+	}))
+	defer server.Close()
+
+	client := slack.NewClient("xoxp-test", slack.WithBaseURL(server.URL))
+	cmd := &SearchMessagesCmd{Context: 2}
+	hit := searchHit{Match: slack.SearchMatch{TS: "1700000000.000100", Channel: slack.SearchChannel{ID: "C1"}}}
+	if err := cmd.expand(client, &hit); err != nil {
+		t.Fatalf("expand returned error: %v", err)
+	}
+
+	if got := timestamps(hit.Before); !reflect.DeepEqual(got, []string{"1699999000.000000"}) {
+		t.Fatalf("unexpected messages before: %v", got)
+	}
+	if got := timestamps(hit.After); !reflect.DeepEqual(got, []string{"1700000600.000000", "1700010800.000000"}) {
+		t.Fatalf("unexpected messages after: %v", got)
+	}
+}
+
+func TestMatchThreadTS(t *testing.T) {
+	tests := map[string]string{
+		"https://acme.slack.com/archives/C1/p1700000002000000?thread_ts=1700000001.000000&cid=C1": "1700000001.000000",
+		"https://acme.slack.com/archives/C1/p1700000002000000":                                    "",
+		"https://acme.slack.com/archives/C1/p1700000002000000?thread_ts=1700000002.000000":        "",
+	}
+	for permalink, want := range tests {
+		match := slack.SearchMatch{TS: "1700000002.000000", Permalink: permalink}
+		if got := matchThreadTS(match); got != want {
+			t.Fatalf("matchThreadTS(%q) = %q, want %q", permalink, got, want)
+		}
+	}
+}
+
+func TestGroupByChannel(t *testing.T) {
+	hits := []searchHit{
+		{Match: slack.SearchMatch{TS: "1", Channel: slack.SearchChannel{ID: "C2", Name: "ops"}}},
+		{Match: slack.SearchMatch{TS: "2", Channel: slack.SearchChannel{ID: "C1", Name: "general"}}},
+		{Match: slack.SearchMatch{TS: "3", Channel: slack.SearchChannel{ID: "C2", Name: "ops"}}},
+	}
+
+	groups := groupByChannel(hits)
+	if len(groups) != 2 || groups[0].name() != "ops" || groups[1].name() != "general" {
+		t.Fatalf("unexpected groups: %+v", groups)
+	}
+	if len(groups[0].Hits) != 2 || groups[0].Hits[1].Match.TS != "3" {
+		t.Fatalf("expected both #ops hits in result order, got %+v", groups[0].Hits)
+	}
+}
+
+func timestamps(messages []slack.Message) []string {
+	var out []string
+	for _, msg := range messages {
+		out = append(out, msg.TS)
+	}
+	return out
+}
+
+func TestSearchAllItemsAreTagged(t *testing.T) {
+	doc := searchAllJSON{
+		Messages: searchJSON{Matches: []searchMatchJSON{{Channel: "C1", TS: "1700000000.000100", Text: "hi"}}},
+		Files:    searchFilesJSON{Files: []searchFileJSON{{fileJSON: fileJSON{ID: "F1", Name: "a.pdf"}}}},
+	}
+
+	var types []string
+	for _, item := range doc.items() {
+		data, err := json.Marshal(item)
+		if err != nil {
+			t.Fatalf("Marshal returned error: %v", err)
+		}
+		var line struct {
+			Type string `json:"type"`
+			TS   string `json:"ts"`
+			ID   string `json:"id"`
+		}
+		if err := json.Unmarshal(data, &line); err != nil {
+			t.Fatalf("Unmarshal returned error: %v", err)
+		}
+		types = append(types, line.Type+":"+line.TS+line.ID)
+	}
+
+	if want := []string{"message:1700000000.000100", "file:F1"}; !reflect.DeepEqual(types, want) {
+		t.Fatalf("expected %v, got %v", want, types)
+	}
+}
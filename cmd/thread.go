@@ -2,19 +2,28 @@ package cmd
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"strings"
 
+	"github.com/lox/slack-cli/internal/output"
 	"github.com/lox/slack-cli/internal/slack"
 )
 
 type ThreadCmd struct {
-	Read ThreadReadCmd `cmd:"" help:"Read a thread by URL or channel+timestamp"`
+	Read  ThreadReadCmd  `cmd:"" help:"Read a thread by URL or channel+timestamp"`
+	Reply ThreadReplyCmd `cmd:"" help:"Reply to a thread"`
 }
 
 type ThreadReadCmd struct {
 	URL       string `arg:"" optional:"" help:"Thread URL (e.g., https://workspace.slack.com/archives/C123/p1234567890)"`
 	Channel   string `help:"Channel ID" short:"c"`
 	Timestamp string `help:"Thread timestamp" short:"t"`
-	Limit     int    `help:"Maximum number of replies" default:"100"`
+	Limit     int    `help:"Maximum number of messages, including the parent (0 for all)" default:"100"`
+	Reactors  bool   `help:"Show who reacted to each message"`
+	Follow    bool   `help:"Keep watching for new and edited replies until interrupted (JSON output becomes NDJSON)" short:"f"`
+
+	TimeRange TimeRangeFlags `embed:""`
 }
 
 func (c *ThreadReadCmd) Run(ctx *Context) error {
@@ -33,22 +42,101 @@ func (c *ThreadReadCmd) Run(ctx *Context) error {
 		return fmt.Errorf("provide either a thread URL or --channel and --timestamp")
 	}
 
+	opts, err := c.TimeRange.historyOptions(c.Limit)
+	if err != nil {
+		return err
+	}
+	if c.Follow && c.TimeRange.Until != "" {
+		return fmt.Errorf("--follow can't be combined with --until")
+	}
+
 	client, err := ctx.NewClient(c.URL)
 	if err != nil {
 		return err
 	}
-	resolver := slack.NewResolver(client)
+	resolver := ctx.newResolver(client)
 
-	replies, err := client.GetConversationReplies(channelID, threadTS, c.Limit)
+	replies, err := client.GetConversationReplies(channelID, threadTS, opts)
 	if err != nil {
 		err = ctx.augmentChannelNotFoundError(c.URL, err)
 		return fmt.Errorf("failed to get thread: %w", err)
 	}
+	if c.Follow {
+		return follow(ctx, client, resolver, channelID, threadTS, replies.Messages, c.Reactors)
+	}
+	resolver.Prefetch(replies.Messages)
 
-	for _, msg := range replies.Messages {
-		user := resolver.ResolveUser(msg.User)
-		fmt.Printf("[%s] %s: %s\n", msg.TS, user, resolver.FormatText(msg.Text))
+	switch format := ctx.format(); {
+	case format.Structured():
+		info, err := client.GetConversationInfo(channelID)
+		if err != nil {
+			return fmt.Errorf("failed to get channel info: %w", err)
+		}
+		doc := messagesJSON{
+			Channel:    newChannelJSON(*info),
+			ThreadTS:   threadTS,
+			Messages:   newMessagesJSON(resolver, channelID, replies.Messages),
+			HasMore:    replies.HasMore,
+			NextCursor: replies.ResponseMetadata.NextCursor,
+		}
+		return writeStructured(format, doc, doc.Messages)
+	case format == output.FormatMarkdown:
+		var sb strings.Builder
+		formatter := &messageFormatter{resolver: resolver, reactors: c.Reactors}
+		formatter.writeThread(&sb, replies.Messages)
+		fmt.Print(sb.String())
+	default:
+		printMessages(resolver, replies.Messages, c.Reactors)
+	}
+
+	if replies.HasMore {
+		printMoreHint("replies")
 	}
 
 	return nil
 }
+
+type ThreadReplyCmd struct {
+	URL       string `arg:"" help:"Thread or message URL"`
+	Broadcast bool   `help:"Also send the reply to the channel"`
+
+	Input messageInput `embed:""`
+}
+
+func (c *ThreadReplyCmd) Run(ctx *Context) error {
+	channelID, threadTS, err := parseThreadParent(c.URL)
+	if err != nil {
+		return err
+	}
+
+	text, opts, err := c.Input.compose(os.Stdin)
+	if err != nil {
+		return err
+	}
+	opts.ThreadTS = threadTS
+	opts.ReplyBroadcast = c.Broadcast
+
+	client, err := ctx.NewClient(c.URL)
+	if err != nil {
+		return err
+	}
+
+	if err := postMessage(ctx, client, channelID, text, opts); err != nil {
+		return ctx.augmentChannelNotFoundError(c.URL, err)
+	}
+	return nil
+}
+
+// parseThreadParent returns the channel and parent timestamp of the thread a
+// URL links to, for posting into that thread.
+func parseThreadParent(rawURL string) (string, string, error) {
+	channelID, threadTS, err := slack.ParseThreadURL(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse thread URL: %w", err)
+	}
+	// Links to replies carry the parent's timestamp in the query string.
+	if u, err := url.Parse(rawURL); err == nil && u.Query().Get("thread_ts") != "" {
+		threadTS = u.Query().Get("thread_ts")
+	}
+	return channelID, threadTS, nil
+}
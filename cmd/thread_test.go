@@ -0,0 +1,13 @@
+package cmd
+
+import "testing"
+
+func TestParseThreadParentPrefersThreadTS(t *testing.T) {
+	channelID, threadTS, err := parseThreadParent("https://acme.slack.com/archives/C123/p1700000002000200?thread_ts=1700000001.000100&cid=C123")
+	if err != nil {
+		t.Fatalf("parseThreadParent returned error: %v", err)
+	}
+	if channelID != "C123" || threadTS != "1700000001.000100" {
+		t.Fatalf("expected the parent thread, got %s %s", channelID, threadTS)
+	}
+}
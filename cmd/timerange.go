@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lox/slack-cli/internal/slack"
+	"github.com/lox/slack-cli/internal/timeparse"
+)
+
+// TimeRangeFlags are shared by commands that read message history.
+type TimeRangeFlags struct {
+	Since string `help:"Only show messages at or after this time (RFC3339, 2024-05-01, Slack ts, 2d, yesterday, 'last monday 9am')"`
+	Until string `help:"Only show messages at or before this time (same formats as --since)"`
+}
+
+// historyOptions converts the flags to Slack history bounds.
+func (f TimeRangeFlags) historyOptions(limit int) (slack.HistoryOptions, error) {
+	opts := slack.HistoryOptions{Limit: limit, Inclusive: true}
+	now := time.Now()
+
+	var since, until time.Time
+	if f.Since != "" {
+		t, err := timeparse.Parse(f.Since, now)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --since: %w", err)
+		}
+		since = t
+		opts.Oldest = slack.FormatTS(t)
+	}
+	if f.Until != "" {
+		t, err := timeparse.Parse(f.Until, now)
+		if err != nil {
+			return opts, fmt.Errorf("invalid --until: %w", err)
+		}
+		until = t
+		opts.Latest = slack.FormatTS(t)
+	}
+
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		return opts, fmt.Errorf("--until (%s) is before --since (%s)", until.Format(time.RFC3339), since.Format(time.RFC3339))
+	}
+
+	return opts, nil
+}
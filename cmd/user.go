@@ -2,17 +2,21 @@ package cmd
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/lox/slack-cli/internal/slack"
 )
 
+var userIDPattern = regexp.MustCompile(`^[UW][A-Z0-9]{6,}$`)
+
 type UserCmd struct {
 	List UserListCmd `cmd:"" help:"List users in the workspace"`
 	Info UserInfoCmd `cmd:"" help:"Show user information"`
 }
 
 type UserListCmd struct {
-	Limit int `help:"Maximum number of users to list" default:"100"`
+	Limit int `help:"Maximum number of users to list (0 for all)" default:"100"`
 }
 
 func (c *UserListCmd) Run(ctx *Context) error {
@@ -25,6 +29,21 @@ func (c *UserListCmd) Run(ctx *Context) error {
 		return fmt.Errorf("failed to list users: %w", err)
 	}
 
+	if format := ctx.format(); format.Structured() {
+		doc := userListJSON{
+			Users:      make([]userJSON, 0, len(resp.Members)),
+			HasMore:    resp.HasMore,
+			NextCursor: resp.ResponseMetadata.NextCursor,
+		}
+		for _, user := range resp.Members {
+			if user.Deleted || user.IsBot {
+				continue
+			}
+			doc.Users = append(doc.Users, newUserJSON(user))
+		}
+		return writeStructured(format, doc, doc.Users)
+	}
+
 	for _, user := range resp.Members {
 		if user.Deleted || user.IsBot {
 			continue
@@ -36,11 +55,15 @@ func (c *UserListCmd) Run(ctx *Context) error {
 		fmt.Printf("@%s - %s (%s)\n", user.Name, name, user.Profile.Title)
 	}
 
+	if resp.HasMore {
+		printMoreHint("users")
+	}
+
 	return nil
 }
 
 type UserInfoCmd struct {
-	User string `arg:"" help:"User ID or email"`
+	User string `arg:"" help:"User ID, email or @username"`
 }
 
 func (c *UserInfoCmd) Run(ctx *Context) error {
@@ -49,19 +72,16 @@ func (c *UserInfoCmd) Run(ctx *Context) error {
 		return err
 	}
 
-	var user *slack.User
-
-	// Check if it looks like an email
-	if len(c.User) > 0 && c.User[0] != 'U' && contains(c.User, "@") {
-		user, err = client.LookupUserByEmail(c.User)
-	} else {
-		user, err = client.GetUserInfo(c.User)
-	}
-
+	user, err := lookupUser(client, ctx.newResolver(client), c.User)
 	if err != nil {
 		return fmt.Errorf("failed to get user info: %w", err)
 	}
 
+	if format := ctx.format(); format.Structured() {
+		doc := newUserJSON(*user)
+		return writeStructured(format, doc, []userJSON{doc})
+	}
+
 	fmt.Printf("Name: %s\n", user.RealName)
 	fmt.Printf("Username: @%s\n", user.Name)
 	fmt.Printf("ID: %s\n", user.ID)
@@ -78,11 +98,63 @@ func (c *UserInfoCmd) Run(ctx *Context) error {
 	return nil
 }
 
-func contains(s, substr string) bool {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
+// lookupUser finds a user by ID, email address, or @username. Usernames are
+// first looked up among the names in the resolver's cache, then matched
+// against every user's username, display name and real name, in that order.
+func lookupUser(client *slack.Client, resolver *slack.Resolver, ref string) (*slack.User, error) {
+	switch {
+	case strings.HasPrefix(ref, "@"):
+	case strings.Contains(ref, "@"):
+		return client.LookupUserByEmail(ref)
+	case userIDPattern.MatchString(ref):
+		return client.GetUserInfo(ref)
+	}
+
+	name := strings.TrimPrefix(ref, "@")
+	for _, id := range resolver.CachedUserIDs(name) {
+		user, err := client.GetUserInfo(id)
+		if err == nil && !user.Deleted && userHasName(user, name) {
+			return user, nil
+		}
+	}
+
+	var byDisplay, byReal *slack.User
+	pager := client.UsersPager(0)
+	for {
+		users, err := pager.Next()
+		if err != nil {
+			return nil, err
+		}
+		if users == nil {
+			break
 		}
+		for i := range users {
+			user := &users[i]
+			if user.Deleted {
+				continue
+			}
+			switch {
+			case user.Name == name:
+				return user, nil
+			case byDisplay == nil && strings.EqualFold(user.Profile.DisplayName, name):
+				byDisplay = user
+			case byReal == nil && strings.EqualFold(user.RealName, name):
+				byReal = user
+			}
+		}
+	}
+
+	if byDisplay != nil {
+		return byDisplay, nil
 	}
-	return false
+	if byReal != nil {
+		return byReal, nil
+	}
+	return nil, &slack.APIError{Method: "users.list", Code: "user_not_found"}
+}
+
+// userHasName reports whether name is the user's username, display name or
+// real name.
+func userHasName(user *slack.User, name string) bool {
+	return user.Name == name || strings.EqualFold(user.Profile.DisplayName, name) || strings.EqualFold(user.RealName, name)
 }
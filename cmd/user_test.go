@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lox/slack-cli/internal/cache"
+	"github.com/lox/slack-cli/internal/slack"
+)
+
+func TestLookupUserUsesCachedNames(t *testing.T) {
+	var listed int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.info":
+			_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U1","name":"asmith","profile":{"display_name":"Alice"}}}`))
+		case "/users.list":
+			listed++
+			_, _ = w.Write([]byte(`{"ok":true,"members":[{"id":"U2","name":"bob"}]}`))
+		default:
+			t.Errorf("unexpected call to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := slack.NewClient("xoxp-test", slack.WithBaseURL(server.URL))
+	c := cache.New(t.TempDir())
+	first := slack.NewCachedResolver(client, c)
+	first.ResolveUser("U1")
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	resolver := slack.NewCachedResolver(client, c)
+	user, err := lookupUser(client, resolver, "@alice")
+	if err != nil || user.ID != "U1" {
+		t.Fatalf("expected U1, got %+v, %v", user, err)
+	}
+	if listed != 0 {
+		t.Fatalf("expected no users.list calls for a cached name, got %d", listed)
+	}
+
+	user, err = lookupUser(client, resolver, "@bob")
+	if err != nil || user.ID != "U2" {
+		t.Fatalf("expected U2, got %+v, %v", user, err)
+	}
+	if listed != 1 {
+		t.Fatalf("expected users.list for an uncached name, got %d calls", listed)
+	}
+}
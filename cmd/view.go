@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
-	"time"
 
 	"github.com/lox/slack-cli/internal/output"
 	"github.com/lox/slack-cli/internal/slack"
@@ -13,10 +12,13 @@ import (
 type ViewCmd struct {
 	URL      string `arg:"" help:"Slack URL (message, thread, or channel)"`
 	Markdown bool   `help:"Output as markdown instead of terminal formatting" short:"m"`
-	Limit    int    `help:"Maximum messages to show for channels/threads" default:"20"`
+	Limit    int    `help:"Maximum messages to show for channels/threads (0 for all)" default:"20"`
 	Raw      bool   `help:"Don't resolve user/channel mentions" short:"r"`
+	Reactors bool   `help:"Show who reacted to each message"`
 
-	resolver *slack.Resolver
+	DownloadFiles string `help:"Download files attached to the shown messages into this directory" placeholder:"DIR" type:"path"`
+
+	TimeRange TimeRangeFlags `embed:""`
 }
 
 type slackURLInfo struct {
@@ -83,11 +85,16 @@ func (c *ViewCmd) Run(ctx *Context) error {
 		return err
 	}
 
+	opts, err := c.TimeRange.historyOptions(c.Limit)
+	if err != nil {
+		return err
+	}
+
 	client, err := ctx.NewClient(c.URL)
 	if err != nil {
 		return err
 	}
-	c.resolver = slack.NewResolver(client)
+	resolver := ctx.newResolver(client)
 
 	// Get channel info for context
 	channel, err := client.GetConversationInfo(info.Channel)
@@ -96,112 +103,104 @@ func (c *ViewCmd) Run(ctx *Context) error {
 		return fmt.Errorf("failed to get channel info: %w", err)
 	}
 
-	// Build markdown content, then render appropriately
-	md := c.buildMarkdown(client, channel, info)
-
-	if c.Markdown {
-		fmt.Print(md)
-		return nil
-	}
-	return output.RenderMarkdown(md)
-}
-
-func (c *ViewCmd) buildMarkdown(client *slack.Client, channel *slack.Channel, info *slackURLInfo) string {
-	var sb strings.Builder
-
-	// Header
-	channelName := "#" + channel.Name
-	if channel.IsIM || channel.IsMPIM {
-		channelName = "DM"
-	}
-	fmt.Fprintf(&sb, "# %s\n\n", channelName)
-
+	var messages []slack.Message
+	var hasMore bool
+	var nextCursor string
 	if info.MessageTS != "" {
-		c.buildThreadMarkdown(&sb, client, info)
+		replies, err := client.GetConversationReplies(info.Channel, info.ThreadTS, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get thread: %w", err)
+		}
+		messages, hasMore = replies.Messages, replies.HasMore
+		nextCursor = replies.ResponseMetadata.NextCursor
 	} else {
-		c.buildChannelMarkdown(&sb, client, info.Channel)
+		history, err := client.GetConversationHistory(info.Channel, opts)
+		if err != nil {
+			return fmt.Errorf("failed to get channel history: %w", err)
+		}
+		messages, hasMore = oldestFirst(history.Messages), history.HasMore
+		nextCursor = history.ResponseMetadata.NextCursor
 	}
+	resolver.Prefetch(messages)
 
-	return sb.String()
-}
-
-func (c *ViewCmd) buildThreadMarkdown(sb *strings.Builder, client *slack.Client, info *slackURLInfo) {
-	replies, err := client.GetConversationReplies(info.Channel, info.ThreadTS, c.Limit)
-	if err != nil {
-		fmt.Fprintf(sb, "Error: %v\n", err)
-		return
+	format := ctx.format()
+	if c.Markdown {
+		format = output.FormatMarkdown
 	}
 
-	for i, msg := range replies.Messages {
-		username := c.resolver.ResolveUser(msg.User)
-		timestamp := c.formatTimestamp(msg.TS)
-		text := c.formatText(msg.Text)
+	title := conversationTitle(client, resolver, *channel)
+	if err := c.render(format, resolver, channel, title, info, messages, hasMore, nextCursor); err != nil {
+		return err
+	}
 
-		if i == 0 {
-			fmt.Fprintf(sb, "**%s** _%s_\n\n", username, timestamp)
-			fmt.Fprintf(sb, "%s\n\n", text)
-			if len(replies.Messages) > 1 {
-				fmt.Fprintf(sb, "---\n\n**%d replies**\n\n", len(replies.Messages)-1)
-			}
-		} else {
-			fmt.Fprintf(sb, "> **%s** _%s_\n>\n", username, timestamp)
-			// Indent multiline text in blockquote
-			lines := strings.Split(text, "\n")
-			for _, line := range lines {
-				fmt.Fprintf(sb, "> %s\n", line)
-			}
-			sb.WriteString("\n")
+	if c.DownloadFiles != "" {
+		var files []slack.File
+		for _, msg := range messages {
+			files = append(files, msg.Files...)
+		}
+		if _, err := downloadFiles(client, files, c.DownloadFiles); err != nil {
+			return err
 		}
 	}
+	return nil
 }
 
-func (c *ViewCmd) buildChannelMarkdown(sb *strings.Builder, client *slack.Client, channelID string) {
-	history, err := client.GetConversationHistory(channelID, c.Limit)
-	if err != nil {
-		fmt.Fprintf(sb, "Error: %v\n", err)
-		return
+func (c *ViewCmd) render(format output.Format, resolver *slack.Resolver, channel *slack.Channel, title string, info *slackURLInfo, messages []slack.Message, hasMore bool, nextCursor string) error {
+	if format.Structured() {
+		doc := messagesJSON{
+			Channel:    newChannelJSON(*channel),
+			Messages:   newMessagesJSON(resolver, info.Channel, messages),
+			HasMore:    hasMore,
+			NextCursor: nextCursor,
+		}
+		if info.MessageTS != "" {
+			doc.ThreadTS = info.ThreadTS
+		}
+		return writeStructured(format, doc, doc.Messages)
 	}
 
-	// Reverse to show oldest first
-	for i := len(history.Messages) - 1; i >= 0; i-- {
-		msg := history.Messages[i]
-		username := c.resolver.ResolveUser(msg.User)
-		timestamp := c.formatTimestamp(msg.TS)
-		text := c.formatText(msg.Text)
-
-		fmt.Fprintf(sb, "**%s** _%s_\n\n", username, timestamp)
-		fmt.Fprintf(sb, "%s\n\n", text)
+	// Build markdown content, then render appropriately
+	formatter := &messageFormatter{resolver: resolver, raw: c.Raw, reactors: c.Reactors}
+	md := c.buildMarkdown(formatter, title, info, messages)
 
-		if msg.ReplyCount > 0 {
-			fmt.Fprintf(sb, "_(%d replies)_\n\n", msg.ReplyCount)
-		}
-		sb.WriteString("---\n\n")
+	if format == output.FormatMarkdown {
+		fmt.Print(md)
+		return nil
 	}
+	return output.RenderMarkdown(md)
 }
 
-func (c *ViewCmd) formatText(text string) string {
-	if c.Raw {
-		return text
-	}
-	return c.resolver.FormatText(text)
-}
+func (c *ViewCmd) buildMarkdown(formatter *messageFormatter, title string, info *slackURLInfo, messages []slack.Message) string {
+	var sb strings.Builder
 
-func (c *ViewCmd) formatTimestamp(ts string) string {
-	parts := strings.Split(ts, ".")
-	if len(parts) == 0 {
-		return ts
+	// Header
+	fmt.Fprintf(&sb, "# %s\n\n", title)
+
+	if info.MessageTS != "" {
+		formatter.writeThread(&sb, messages)
+	} else {
+		formatter.writeChannel(&sb, messages)
 	}
 
-	var sec int64
-	_, _ = fmt.Sscanf(parts[0], "%d", &sec)
-	t := time.Unix(sec, 0)
+	return sb.String()
+}
 
-	now := time.Now()
-	if t.Year() == now.Year() && t.YearDay() == now.YearDay() {
-		return t.Format("3:04 PM")
-	}
-	if t.Year() == now.Year() {
-		return t.Format("Jan 2, 3:04 PM")
+// conversationTitle names a conversation for headers. DMs are titled with the
+// people in them, falling back to the names in a group DM's generated name if
+// its members can't be listed.
+func conversationTitle(client *slack.Client, resolver *slack.Resolver, ch slack.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "DM with @" + resolver.ResolveUser(ch.User)
+	case ch.IsMPIM:
+		names := mpimMembers(ch.Name)
+		if members, err := client.GetConversationMembers(ch.ID); err == nil {
+			names = names[:0]
+			for _, userID := range members {
+				names = append(names, resolver.ResolveUser(userID))
+			}
+		}
+		return "Group DM with " + strings.Join(names, ", ")
 	}
-	return t.Format("Jan 2, 2006 3:04 PM")
+	return "#" + ch.Name
 }
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -14,7 +15,8 @@ func (ctx *Context) augmentChannelNotFoundError(rawURL string, err error) error
 	if strings.TrimSpace(ctx.Workspace) != "" {
 		return err
 	}
-	if !strings.Contains(err.Error(), "slack API error: channel_not_found") {
+	var apiErr *slack.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != "channel_not_found" {
 		return err
 	}
 
@@ -1,15 +1,16 @@
 package cmd
 
 import (
-	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/lox/slack-cli/internal/config"
+	"github.com/lox/slack-cli/internal/slack"
 )
 
 func TestAugmentChannelNotFoundError(t *testing.T) {
-	baseErr := errors.New("slack API error: channel_not_found")
+	baseErr := &slack.APIError{Method: "conversations.info", Code: "channel_not_found"}
 
 	t.Run("adds config hint for unmapped workspace URL", func(t *testing.T) {
 		ctx := &Context{Config: &config.Config{Workspaces: map[string]config.WorkspaceAuth{}}}
@@ -44,11 +45,29 @@ func TestAugmentChannelNotFoundError(t *testing.T) {
 	})
 
 	t.Run("does not add hint for non channel_not_found errors", func(t *testing.T) {
-		otherErr := errors.New("slack API error: not_in_channel")
+		otherErr := &slack.APIError{Method: "conversations.replies", Code: "not_in_channel"}
 		ctx := &Context{Config: &config.Config{Workspaces: map[string]config.WorkspaceAuth{}}}
 		err := ctx.augmentChannelNotFoundError("https://buildkite.slack.com/archives/C123/p1234567890123456", otherErr)
 		if err.Error() != otherErr.Error() {
 			t.Fatalf("expected original error, got %q", err.Error())
 		}
 	})
+
+	t.Run("does not add hint for message_not_found errors", func(t *testing.T) {
+		otherErr := &slack.APIError{Method: "conversations.history", Code: "message_not_found"}
+		ctx := &Context{Config: &config.Config{Workspaces: map[string]config.WorkspaceAuth{}}}
+		err := ctx.augmentChannelNotFoundError("https://buildkite.slack.com/archives/C123/p1234567890123456", otherErr)
+		if err != otherErr {
+			t.Fatalf("expected original error, got %q", err.Error())
+		}
+	})
+
+	t.Run("adds config hint for wrapped channel_not_found errors", func(t *testing.T) {
+		ctx := &Context{Config: &config.Config{Workspaces: map[string]config.WorkspaceAuth{}}}
+		wrapped := fmt.Errorf("failed to get channel info: %w", baseErr)
+		err := ctx.augmentChannelNotFoundError("https://buildkite.slack.com/archives/C123/p1234567890123456", wrapped)
+		if !strings.Contains(err.Error(), "Workspace buildkite.slack.com is not configured") {
+			t.Fatalf("expected workspace configuration hint, got %q", err.Error())
+		}
+	})
 }
@@ -2,7 +2,10 @@ module github.com/lox/slack-cli
 
 go 1.23.0
 
-require github.com/alecthomas/kong v1.11.0
+require (
+	github.com/alecthomas/kong v1.11.0
+	golang.org/x/net v0.33.0
+)
 
 require (
 	github.com/alecthomas/chroma/v2 v2.14.0 // indirect
@@ -28,7 +31,6 @@ require (
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	github.com/yuin/goldmark v1.7.8 // indirect
 	github.com/yuin/goldmark-emoji v1.0.5 // indirect
-	golang.org/x/net v0.33.0 // indirect
 	golang.org/x/sys v0.32.0 // indirect
 	golang.org/x/term v0.31.0 // indirect
 	golang.org/x/text v0.24.0 // indirect
@@ -0,0 +1,191 @@
+// Package cache stores JSON documents on disk so that lookups can be reused
+// across invocations of the CLI.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Cache is a directory of JSON documents, each stored with the time it was
+// written so readers can decide whether it is still fresh.
+type Cache struct {
+	dir string
+	now func() time.Time
+}
+
+type entry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// Dir returns the default cache directory, under the user's cache directory
+// ($XDG_CACHE_HOME or ~/.cache on Linux).
+func Dir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "slack-cli"), nil
+}
+
+// New returns a Cache that stores documents in dir, creating it on first write.
+func New(dir string) *Cache {
+	return &Cache{dir: dir, now: time.Now}
+}
+
+// Sub returns a Cache stored in a subdirectory, e.g. one per workspace.
+func (c *Cache) Sub(name string) *Cache {
+	return &Cache{dir: filepath.Join(c.dir, fileName(name)), now: c.now}
+}
+
+// Get decodes the document stored under key into v, reporting whether it was
+// found and saved less than ttl ago. Unreadable documents count as missing.
+func (c *Cache) Get(key string, ttl time.Duration, v any) bool {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return false
+	}
+
+	var e entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return false
+	}
+	if c.now().Sub(e.SavedAt) >= ttl {
+		return false
+	}
+	return json.Unmarshal(e.Data, v) == nil
+}
+
+// Set stores v under key. The document is written to a temporary file and
+// renamed into place so concurrent readers never see a partial write.
+func (c *Cache) Set(key string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data, err = json.Marshal(entry{SavedAt: c.now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(c.dir, "."+fileName(key)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close() //nolint:errcheck
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// Delete removes the document stored under key, if there is one.
+func (c *Cache) Delete(key string) error {
+	if err := os.Remove(c.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Clear removes every document in the cache, including subdirectories.
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Stat describes a stored document.
+type Stat struct {
+	Key     string
+	Size    int64
+	SavedAt time.Time
+	// Items is the number of entries in the document, if it is a JSON array
+	// or object.
+	Items int
+}
+
+// Stats describes the documents in the cache, not counting subdirectories.
+func (c *Cache) Stats() ([]Stat, error) {
+	files, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []Stat
+	for _, f := range files {
+		key, ok := strings.CutSuffix(f.Name(), ".json")
+		if !ok || f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(c.dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		stat := Stat{Key: key, Size: int64(len(data))}
+		var e entry
+		if json.Unmarshal(data, &e) == nil {
+			stat.SavedAt = e.SavedAt
+			var items []json.RawMessage
+			var fields map[string]json.RawMessage
+			if json.Unmarshal(e.Data, &items) == nil {
+				stat.Items = len(items)
+			} else if json.Unmarshal(e.Data, &fields) == nil {
+				stat.Items = len(fields)
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
+// Subs lists the names of the cache's subdirectories.
+func (c *Cache) Subs() ([]string, error) {
+	files, err := os.ReadDir(c.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, f := range files {
+		if f.IsDir() {
+			names = append(names, f.Name())
+		}
+	}
+	return names, nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, fileName(key)+".json")
+}
+
+// fileName makes a key safe to use as a file name.
+func fileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, key)
+	if strings.Trim(name, ".") == "" {
+		return "_" + name
+	}
+	return name
+}
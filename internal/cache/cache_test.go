@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGetReturnsFreshDocuments(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c := New(t.TempDir())
+	c.now = func() time.Time { return now }
+
+	if err := c.Set("channels", []string{"general"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	var got []string
+	if !c.Get("channels", time.Hour, &got) || len(got) != 1 || got[0] != "general" {
+		t.Fatalf("expected cached document, got %v", got)
+	}
+
+	now = now.Add(time.Hour)
+	if c.Get("channels", time.Hour, &got) {
+		t.Fatal("expected document older than the TTL to be treated as missing")
+	}
+}
+
+func TestGetMissingDocument(t *testing.T) {
+	var got []string
+	if New(t.TempDir()).Get("channels", time.Hour, &got) {
+		t.Fatal("expected missing document")
+	}
+}
+
+func TestSubKeepsWorkspacesApart(t *testing.T) {
+	dir := t.TempDir()
+	root := New(dir)
+
+	if err := root.Sub("acme.slack.com").Set("channels", "acme"); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	var got string
+	if root.Sub("other.slack.com").Get("channels", time.Hour, &got) {
+		t.Fatal("expected workspaces not to share documents")
+	}
+	if !root.Sub("acme.slack.com").Get("channels", time.Hour, &got) || got != "acme" {
+		t.Fatalf("expected acme document, got %q", got)
+	}
+}
+
+func TestFileNameStaysInDirectory(t *testing.T) {
+	for _, key := range []string{"../escape", "..", "a/b"} {
+		name := fileName(key)
+		if filepath.Base(name) != name || name == ".." || name == "." {
+			t.Fatalf("fileName(%q) = %q, which is not a plain file name", key, name)
+		}
+	}
+}
+
+func TestStatsAndClear(t *testing.T) {
+	root := New(t.TempDir())
+	c := root.Sub("acme.slack.com")
+
+	if err := c.Set("users", map[string]string{"U1": "alice", "U2": "bob"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+	if err := c.Set("channels", []string{"general"}); err != nil {
+		t.Fatalf("Set returned error: %v", err)
+	}
+
+	subs, err := root.Subs()
+	if err != nil || len(subs) != 1 || subs[0] != "acme.slack.com" {
+		t.Fatalf("expected one workspace, got %v (%v)", subs, err)
+	}
+
+	stats, err := c.Stats()
+	if err != nil {
+		t.Fatalf("Stats returned error: %v", err)
+	}
+	items := map[string]int{}
+	for _, stat := range stats {
+		items[stat.Key] = stat.Items
+	}
+	if items["users"] != 2 || items["channels"] != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	if err := root.Clear(); err != nil {
+		t.Fatalf("Clear returned error: %v", err)
+	}
+	if stats, err := c.Stats(); err != nil || len(stats) != 0 {
+		t.Fatalf("expected an empty cache after Clear, got %v (%v)", stats, err)
+	}
+}
@@ -15,6 +15,9 @@ type WorkspaceAuth struct {
 	Team         string `json:"team,omitempty"`
 	TeamID       string `json:"team_id,omitempty"`
 	URL          string `json:"url,omitempty"`
+	// AppToken is an app-level token (xapp-...) used to receive events over
+	// Socket Mode.
+	AppToken string `json:"app_token,omitempty"`
 }
 
 type Config struct {
@@ -23,7 +26,13 @@ type Config struct {
 	ClientSecret     string                   `json:"client_secret,omitempty"`
 	CurrentWorkspace string                   `json:"current_workspace,omitempty"`
 	Workspaces       map[string]WorkspaceAuth `json:"workspaces,omitempty"`
-	path             string
+	// APIURL replaces the Slack Web API base URL, e.g. to go through a proxy
+	// or reach a local mock. SLACK_API_URL overrides it.
+	APIURL string `json:"api_url,omitempty"`
+	// CABundle is a PEM file of extra certificate authorities to trust when
+	// connecting to Slack. SLACK_CA_BUNDLE overrides it.
+	CABundle string `json:"ca_bundle,omitempty"`
+	path     string
 }
 
 func configPath() (string, error) {
@@ -147,6 +156,11 @@ func (c *Config) SetWorkspaceAuth(workspace string, auth WorkspaceAuth) {
 		c.Workspaces = map[string]WorkspaceAuth{}
 	}
 
+	// Logging in again keeps the workspace's app-level token.
+	if auth.AppToken == "" {
+		auth.AppToken = c.Workspaces[workspace].AppToken
+	}
+
 	c.Workspaces[workspace] = auth
 	c.CurrentWorkspace = workspace
 	c.Token = auth.Token
@@ -169,6 +183,39 @@ func (c *Config) SetWorkspaceCredentials(workspace, clientID, clientSecret strin
 	c.CurrentWorkspace = workspace
 }
 
+// SetWorkspaceAppToken saves an app-level token for a workspace without
+// changing the current workspace.
+func (c *Config) SetWorkspaceAppToken(workspace, token string) {
+	workspace = c.workspaceKeyOrInput(workspace)
+	if workspace == "" {
+		return
+	}
+
+	if c.Workspaces == nil {
+		c.Workspaces = map[string]WorkspaceAuth{}
+	}
+
+	auth := c.Workspaces[workspace]
+	auth.AppToken = strings.TrimSpace(token)
+	c.Workspaces[workspace] = auth
+}
+
+// AppTokenForWorkspace returns the app-level token saved for a workspace, or
+// for the current workspace if none is given.
+func (c *Config) AppTokenForWorkspace(workspace string) (token string, resolvedWorkspace string, err error) {
+	resolvedWorkspace, err = c.ResolveWorkspace(workspace)
+	if err != nil {
+		return "", "", err
+	}
+
+	token = c.Workspaces[resolvedWorkspace].AppToken
+	if token == "" {
+		return "", "", fmt.Errorf("no app-level token configured for workspace %q. Run 'slack-cli auth app-token' first", resolvedWorkspace)
+	}
+
+	return token, resolvedWorkspace, nil
+}
+
 func (c *Config) OAuthCredentialsForWorkspace(workspace string) (clientID, clientSecret, resolvedWorkspace string, err error) {
 	workspace = normalizeWorkspaceKey(workspace)
 
@@ -253,3 +253,32 @@ func TestOAuthCredentialsForWorkspace(t *testing.T) {
 		}
 	})
 }
+
+func TestWorkspaceAppToken(t *testing.T) {
+	cfg := &Config{
+		CurrentWorkspace: "buildkite.slack.com",
+		Workspaces: map[string]WorkspaceAuth{
+			"buildkite.slack.com": {Token: "xoxp", TeamID: "TBUILD"},
+			"other.slack.com":     {Token: "xoxp-other"},
+		},
+	}
+
+	cfg.SetWorkspaceAppToken("TBUILD", " xapp-1 ")
+
+	tok, workspace, err := cfg.AppTokenForWorkspace("")
+	if err != nil {
+		t.Fatalf("AppTokenForWorkspace returned error: %v", err)
+	}
+	if tok != "xapp-1" || workspace != "buildkite.slack.com" {
+		t.Fatalf("expected xapp-1 for buildkite.slack.com, got %q for %q", tok, workspace)
+	}
+
+	if _, _, err := cfg.AppTokenForWorkspace("other"); err == nil {
+		t.Fatal("expected an error for a workspace without an app-level token")
+	}
+
+	cfg.SetWorkspaceAuth("buildkite.slack.com", WorkspaceAuth{Token: "xoxp-new", TeamID: "TBUILD"})
+	if got := cfg.Workspaces["buildkite.slack.com"].AppToken; got != "xapp-1" {
+		t.Fatalf("expected logging in again to keep the app-level token, got %q", got)
+	}
+}
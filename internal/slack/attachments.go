@@ -0,0 +1,138 @@
+package slack
+
+import "strings"
+
+// RenderAttachments renders legacy attachments and link unfurls as quoted
+// cards, as plain text or as CommonMark when markdown is true. Fields are
+// rendered as a table in CommonMark and as "Title: value" lines otherwise.
+func (r *Resolver) RenderAttachments(attachments []Attachment, markdown bool) string {
+	br := &blockRenderer{mrkdwn: &mrkdwnRenderer{resolver: r, markdown: markdown}}
+
+	var cards []string
+	for _, att := range attachments {
+		if card := br.attachment(att); card != "" {
+			cards = append(cards, card)
+		}
+	}
+
+	separator := "\n"
+	if markdown {
+		separator = "\n\n"
+	}
+	return strings.Join(cards, separator)
+}
+
+// attachment renders a single attachment: its pretext above the card, then
+// the author, title, text, blocks, fields, image and footer quoted below.
+func (b *blockRenderer) attachment(att Attachment) string {
+	var parts []string
+
+	author := att.AuthorName
+	if author == "" {
+		author = att.ServiceName
+	}
+	if author != "" {
+		parts = append(parts, b.link(author, att.AuthorLink))
+	}
+
+	if att.Title != "" {
+		titleLink := att.TitleLink
+		if titleLink == "" {
+			titleLink = att.FromURL
+		}
+		title := b.link(att.Title, titleLink)
+		if b.markdown() {
+			title = "**" + title + "**"
+		}
+		parts = append(parts, title)
+	}
+
+	if att.Text != "" {
+		parts = append(parts, b.mrkdwnText(att.Text))
+	}
+	for _, block := range att.Blocks {
+		if out := strings.TrimRight(b.block(block), "\n"); strings.TrimSpace(out) != "" {
+			parts = append(parts, out)
+		}
+	}
+	if len(att.Fields) > 0 {
+		parts = append(parts, b.fields(att.Fields))
+	}
+	if att.ImageURL != "" {
+		parts = append(parts, b.image(att.Title, att.ImageURL))
+	}
+	if footer := b.footer(att); footer != "" {
+		parts = append(parts, footer)
+	}
+
+	if len(parts) == 0 && att.Fallback != "" {
+		parts = append(parts, b.mrkdwnText(att.Fallback))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+
+	separator := "\n"
+	if b.markdown() {
+		separator = "\n\n"
+	}
+	var sb strings.Builder
+	if att.Pretext != "" {
+		sb.WriteString(b.mrkdwnText(att.Pretext) + separator)
+	}
+	for i, line := range strings.Split(strings.Join(parts, separator), "\n") {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if line == "" {
+			sb.WriteString(">")
+			continue
+		}
+		sb.WriteString("> " + line)
+	}
+	return sb.String()
+}
+
+func (b *blockRenderer) mrkdwnText(text string) string {
+	return strings.TrimRight(b.mrkdwn.render(text), "\n")
+}
+
+// fields renders attachment fields as a two-column table in CommonMark, where
+// each value is flattened onto a single line to fit its cell.
+func (b *blockRenderer) fields(fields []AttachmentField) string {
+	var lines []string
+	if !b.markdown() {
+		for _, field := range fields {
+			value := b.mrkdwnText(field.Value)
+			if field.Title == "" {
+				lines = append(lines, value)
+				continue
+			}
+			lines = append(lines, field.Title+": "+value)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	cell := strings.NewReplacer("\\\n", " ", "\n", " ", "|", `\|`)
+	lines = append(lines, "| Field | Value |", "| --- | --- |")
+	for _, field := range fields {
+		title := cell.Replace(b.mrkdwn.literal(field.Title))
+		value := cell.Replace(b.mrkdwnText(field.Value))
+		lines = append(lines, "| "+title+" | "+value+" |")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// footer renders the footer text followed by the attachment's timestamp.
+func (b *blockRenderer) footer(att Attachment) string {
+	var parts []string
+	if att.Footer != "" {
+		parts = append(parts, b.mrkdwnText(att.Footer))
+	}
+	if att.TS != "" {
+		if t, err := ParseTS(att.TS.String()); err == nil {
+			parts = append(parts, t.Local().Format("Jan 2, 2006 3:04 PM"))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
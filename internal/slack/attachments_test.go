@@ -0,0 +1,118 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const alertMessage = `{
+	"type": "message",
+	"text": "",
+	"attachments": [{
+		"color": "#e01e5a",
+		"fallback": "[Triggered] High error rate",
+		"pretext": "New alert from <https://app.example.com|Monitor>",
+		"title": "High error rate on api",
+		"title_link": "https://app.example.com/incidents/7",
+		"text": "Errors are above *5%*\nfor 10 minutes",
+		"fields": [
+			{"title": "Status", "value": "Triggered", "short": true},
+			{"title": "Owner", "value": "<@U1> | oncall", "short": true}
+		],
+		"footer": "Monitor"
+	}]
+}`
+
+func TestFormatMessageAttachments(t *testing.T) {
+	msg := decodeMessage(t, alertMessage)
+	r := newTestResolver(map[string]string{"U1": "alice"}, nil)
+
+	wantText := "New alert from Monitor (https://app.example.com)\n" +
+		"> High error rate on api (https://app.example.com/incidents/7)\n" +
+		"> Errors are above *5%*\n" +
+		"> for 10 minutes\n" +
+		"> Status: Triggered\n" +
+		"> Owner: @alice | oncall\n" +
+		"> Monitor"
+	if got := r.FormatMessage(msg); got != wantText {
+		t.Errorf("FormatMessage\n got: %q\nwant: %q", got, wantText)
+	}
+
+	wantMarkdown := "New alert from [Monitor](https://app.example.com)\n\n" +
+		"> **[High error rate on api](https://app.example.com/incidents/7)**\n" +
+		">\n" +
+		"> Errors are above **5%**\\\n" +
+		"> for 10 minutes\n" +
+		">\n" +
+		"> | Field | Value |\n" +
+		"> | --- | --- |\n" +
+		"> | Status | Triggered |\n" +
+		"> | Owner | @alice \\| oncall |\n" +
+		">\n" +
+		"> Monitor"
+	if got := r.FormatMessageMarkdown(msg); got != wantMarkdown {
+		t.Errorf("FormatMessageMarkdown\n got: %q\nwant: %q", got, wantMarkdown)
+	}
+}
+
+func TestFormatMessageUnfurlAfterText(t *testing.T) {
+	r := newTestResolver(nil, nil)
+	msg := Message{
+		Text: "look <https://github.com/lox/slack-cli>",
+		Attachments: []Attachment{{
+			ServiceName: "GitHub",
+			Title:       "lox/slack-cli",
+			FromURL:     "https://github.com/lox/slack-cli",
+			Text:        "A CLI for Slack",
+		}},
+	}
+
+	want := "look <https://github.com/lox/slack-cli>\n\n" +
+		"> GitHub\n" +
+		">\n" +
+		"> **[lox/slack-cli](https://github.com/lox/slack-cli)**\n" +
+		">\n" +
+		"> A CLI for Slack"
+	if got := r.FormatMessageMarkdown(msg); got != want {
+		t.Errorf("FormatMessageMarkdown\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestRenderAttachmentsFallback(t *testing.T) {
+	r := newTestResolver(nil, nil)
+	got := r.RenderAttachments([]Attachment{{Fallback: "Build #12 passed"}}, false)
+	if got != "> Build #12 passed" {
+		t.Errorf("expected fallback card, got %q", got)
+	}
+}
+
+func TestHistoryToleratesOddAttachmentTimestamps(t *testing.T) {
+	var resp HistoryResponse
+	err := json.Unmarshal([]byte(`{"ok":true,"messages":[
+		{"ts":"1700000000.000100","attachments":[{"text":"numeric","ts":1700000000}]},
+		{"ts":"1700000000.000200","attachments":[{"text":"string","ts":"1700000000.5"}]},
+		{"ts":"1700000000.000300","attachments":[{"text":"odd","ts":"yesterday"}]},
+		{"ts":"1700000000.000400","attachments":[{"text":"object","ts":{"at":1}}]}
+	]}`), &resp)
+	if err != nil {
+		t.Fatalf("expected odd attachment timestamps to be tolerated, got %v", err)
+	}
+
+	var got []string
+	for _, msg := range resp.Messages {
+		got = append(got, msg.Attachments[0].TS.String())
+	}
+	want := []string{"1700000000", "1700000000.5", "yesterday", ""}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected timestamps %q, got %q", want, got)
+	}
+
+	// A timestamp that isn't one is left out of the footer.
+	msg := resp.Messages[2]
+	msg.Attachments[0].Footer = "Monitor"
+	r := newTestResolver(nil, nil)
+	if got := r.FormatMessage(msg); got != "> odd\n> Monitor" {
+		t.Fatalf("expected the footer without a time, got %q", got)
+	}
+}
@@ -0,0 +1,495 @@
+package slack
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Block is a Block Kit layout block. Only the fields needed to render blocks
+// as text are decoded.
+type Block struct {
+	Type      string         `json:"type"`
+	BlockID   string         `json:"block_id,omitempty"`
+	Text      *TextObject    `json:"text,omitempty"`
+	Fields    []TextObject   `json:"fields,omitempty"`
+	Accessory *BlockElement  `json:"accessory,omitempty"`
+	Elements  []BlockElement `json:"elements,omitempty"`
+	ImageURL  string         `json:"image_url,omitempty"`
+	AltText   string         `json:"alt_text,omitempty"`
+	Title     *TextObject    `json:"title,omitempty"`
+	TitleURL  string         `json:"title_url,omitempty"`
+}
+
+// TextObject is a Block Kit text composition object.
+type TextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// BlockElement is an element within a block: a text object, image or
+// interactive element in section, context and actions blocks, or a rich text
+// element within a rich_text block.
+type BlockElement struct {
+	Type        string         `json:"type"`
+	Text        ElementText    `json:"text"`
+	ImageURL    string         `json:"image_url,omitempty"`
+	AltText     string         `json:"alt_text,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	UserID      string         `json:"user_id,omitempty"`
+	ChannelID   string         `json:"channel_id,omitempty"`
+	UsergroupID string         `json:"usergroup_id,omitempty"`
+	Name        string         `json:"name,omitempty"`
+	Unicode     string         `json:"unicode,omitempty"`
+	Range       string         `json:"range,omitempty"`
+	Timestamp   int64          `json:"timestamp,omitempty"`
+	Format      string         `json:"format,omitempty"`
+	Fallback    string         `json:"fallback,omitempty"`
+	Style       ElementStyle   `json:"style"`
+	Indent      int            `json:"indent,omitempty"`
+	Offset      int            `json:"offset,omitempty"`
+	Elements    []BlockElement `json:"elements,omitempty"`
+}
+
+// ElementText holds an element's "text" field, which is a plain string on
+// text objects and rich text elements but a nested text object on buttons
+// and other interactive elements.
+type ElementText struct {
+	Type string
+	Text string
+}
+
+func (t *ElementText) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		t.Text = s
+		return nil
+	}
+
+	var obj TextObject
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	t.Type, t.Text = obj.Type, obj.Text
+	return nil
+}
+
+// ElementStyle holds an element's "style" field, which is an object of text
+// styles on rich text elements and a plain name ("bullet", "primary") on
+// lists and buttons.
+type ElementStyle struct {
+	Name   string
+	Bold   bool `json:"bold"`
+	Italic bool `json:"italic"`
+	Strike bool `json:"strike"`
+	Code   bool `json:"code"`
+}
+
+func (s *ElementStyle) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		s.Name = name
+		return nil
+	}
+
+	type flags ElementStyle
+	var f flags
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+	*s = ElementStyle(f)
+	return nil
+}
+
+// FormatMessage renders a message as plain text, preferring its Block Kit
+// blocks over the text field when present, followed by any attachments.
+func (r *Resolver) FormatMessage(msg Message) string {
+	body := r.RenderBlocks(msg.Blocks, false)
+	if body == "" {
+		body = r.FormatText(msg.Text)
+	}
+	return joinNonEmpty("\n", body, r.RenderAttachments(msg.Attachments, false))
+}
+
+// FormatMessageMarkdown renders a message as CommonMark, preferring its Block
+// Kit blocks over the text field when present, followed by any attachments.
+func (r *Resolver) FormatMessageMarkdown(msg Message) string {
+	body := r.RenderBlocks(msg.Blocks, true)
+	if body == "" {
+		body = r.FormatMarkdown(msg.Text)
+	}
+	return joinNonEmpty("\n\n", body, r.RenderAttachments(msg.Attachments, true))
+}
+
+func joinNonEmpty(separator string, parts ...string) string {
+	var nonEmpty []string
+	for _, part := range parts {
+		if strings.TrimSpace(part) != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, separator)
+}
+
+// RenderBlocks renders Block Kit blocks as plain text, or as CommonMark when
+// markdown is true. Interactive inputs and unknown block types are skipped.
+func (r *Resolver) RenderBlocks(blocks []Block, markdown bool) string {
+	br := &blockRenderer{mrkdwn: &mrkdwnRenderer{resolver: r, markdown: markdown}}
+
+	var parts []string
+	for _, block := range blocks {
+		if out := strings.TrimRight(br.block(block), "\n"); strings.TrimSpace(out) != "" {
+			parts = append(parts, out)
+		}
+	}
+
+	separator := "\n"
+	if markdown {
+		separator = "\n\n"
+	}
+	return strings.Join(parts, separator)
+}
+
+type blockRenderer struct {
+	mrkdwn *mrkdwnRenderer
+}
+
+func (b *blockRenderer) markdown() bool {
+	return b.mrkdwn.markdown
+}
+
+func (b *blockRenderer) block(block Block) string {
+	switch block.Type {
+	case "header":
+		if block.Text == nil {
+			return ""
+		}
+		if b.markdown() {
+			return "### " + b.mrkdwn.literal(block.Text.Text)
+		}
+		return block.Text.Text
+
+	case "section":
+		var lines []string
+		if block.Text != nil {
+			lines = append(lines, b.textObject(*block.Text))
+		}
+		for _, field := range block.Fields {
+			lines = append(lines, b.textObject(field))
+		}
+		if block.Accessory != nil {
+			if out := b.element(*block.Accessory); out != "" {
+				lines = append(lines, out)
+			}
+		}
+		if b.markdown() {
+			return strings.Join(lines, "\n\n")
+		}
+		return strings.Join(lines, "\n")
+
+	case "divider":
+		return "---"
+
+	case "image":
+		title := block.AltText
+		if block.Title != nil && block.Title.Text != "" {
+			title = block.Title.Text
+		}
+		return b.image(title, block.ImageURL)
+
+	case "context":
+		var parts []string
+		for _, el := range block.Elements {
+			if out := b.element(el); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		return strings.Join(parts, " · ")
+
+	case "actions":
+		var parts []string
+		for _, el := range block.Elements {
+			if out := b.element(el); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		return strings.Join(parts, " | ")
+
+	case "video":
+		if block.Title == nil {
+			return ""
+		}
+		return b.link(block.Title.Text, block.TitleURL)
+
+	case "rich_text":
+		var parts []string
+		for _, el := range block.Elements {
+			if out := strings.TrimRight(b.richTextBlock(el), "\n"); out != "" {
+				parts = append(parts, out)
+			}
+		}
+		if b.markdown() {
+			return strings.Join(parts, "\n\n")
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	return ""
+}
+
+func (b *blockRenderer) textObject(obj TextObject) string {
+	if obj.Type == "mrkdwn" {
+		return strings.TrimRight(b.mrkdwn.render(obj.Text), "\n")
+	}
+	return b.mrkdwn.literal(obj.Text)
+}
+
+// element renders a non-rich-text element from a section, context or actions
+// block.
+func (b *blockRenderer) element(el BlockElement) string {
+	switch el.Type {
+	case "mrkdwn", "plain_text":
+		return b.textObject(TextObject{Type: el.Type, Text: el.Text.Text})
+	case "image":
+		return b.image(el.AltText, el.ImageURL)
+	case "button":
+		label := el.Text.Text
+		if el.URL == "" {
+			return b.mrkdwn.literal("[" + label + "]")
+		}
+		return b.link(label, el.URL)
+	}
+	return ""
+}
+
+func (b *blockRenderer) image(title, url string) string {
+	if b.markdown() {
+		return "![" + b.mrkdwn.literal(title) + "](" + escapeLinkDestination(url) + ")"
+	}
+	if title == "" {
+		return "[image] " + url
+	}
+	return "[image: " + title + "] " + url
+}
+
+func (b *blockRenderer) link(label, url string) string {
+	if url == "" {
+		return b.mrkdwn.literal(label)
+	}
+	if b.markdown() {
+		if label == "" {
+			return "<" + url + ">"
+		}
+		return "[" + b.mrkdwn.literal(label) + "](" + escapeLinkDestination(url) + ")"
+	}
+	if label == "" || label == url {
+		return url
+	}
+	return label + " (" + url + ")"
+}
+
+// richTextBlock renders a top-level element of a rich_text block.
+func (b *blockRenderer) richTextBlock(el BlockElement) string {
+	switch el.Type {
+	case "rich_text_section":
+		return b.joinLines(strings.Split(b.richTextInline(el.Elements), "\n"))
+
+	case "rich_text_list":
+		indent := strings.Repeat("    ", el.Indent)
+		var items []string
+		for i, item := range el.Elements {
+			marker := "- "
+			if el.Style.Name == "ordered" {
+				marker = strconv.Itoa(el.Offset+i+1) + ". "
+			} else if !b.markdown() {
+				marker = "• "
+			}
+			text := strings.ReplaceAll(b.richTextInline(item.Elements), "\n", " ")
+			items = append(items, indent+marker+text)
+		}
+		return strings.Join(items, "\n")
+
+	case "rich_text_quote":
+		lines := strings.Split(b.richTextInline(el.Elements), "\n")
+		if !b.markdown() {
+			for i, line := range lines {
+				lines[i] = "> " + line
+			}
+			return strings.Join(lines, "\n")
+		}
+		quoted := strings.Split(b.joinLines(lines), "\n")
+		for i, line := range quoted {
+			quoted[i] = "> " + line
+		}
+		return strings.Join(quoted, "\n")
+
+	case "rich_text_preformatted":
+		var sb strings.Builder
+		for _, child := range el.Elements {
+			sb.WriteString(b.rawText(child))
+		}
+		code := sb.String()
+		if !b.markdown() {
+			return "```" + code + "```"
+		}
+		fence := "```"
+		for strings.Contains(code, fence) {
+			fence += "`"
+		}
+		return fence + "\n" + strings.Trim(code, "\n") + "\n" + fence
+	}
+
+	return ""
+}
+
+// richTextInline renders the inline elements of a rich text section.
+func (b *blockRenderer) richTextInline(elements []BlockElement) string {
+	var sb strings.Builder
+	for _, el := range elements {
+		switch el.Type {
+		case "text":
+			sb.WriteString(b.styled(el.Text.Text, el.Style))
+		case "link":
+			label := el.Text.Text
+			if el.Style.Code {
+				sb.WriteString(b.styled(label, el.Style))
+				continue
+			}
+			sb.WriteString(b.link(label, el.URL))
+		case "user":
+			sb.WriteString(b.mrkdwn.literal("@" + b.mrkdwn.resolver.ResolveUser(el.UserID)))
+		case "channel":
+			sb.WriteString(b.mrkdwn.literal("#" + b.mrkdwn.resolver.ResolveChannel(el.ChannelID)))
+		case "usergroup":
+			handle, ok := b.mrkdwn.resolver.ResolveUsergroup(el.UsergroupID)
+			if !ok {
+				handle = el.UsergroupID
+			}
+			sb.WriteString(b.mrkdwn.literal("@" + handle))
+		case "broadcast":
+			sb.WriteString(b.mrkdwn.literal("@" + el.Range))
+		case "emoji":
+			sb.WriteString(b.emoji(el))
+		case "date":
+			sb.WriteString(b.date(el))
+		}
+	}
+	return sb.String()
+}
+
+// date renders a date element in the viewer's timezone, linking it if the
+// element has a URL.
+func (b *blockRenderer) date(el BlockElement) string {
+	if el.Timestamp == 0 || el.Format == "" {
+		return b.mrkdwn.literal(el.Fallback)
+	}
+	text := formatDate(time.Unix(el.Timestamp, 0).Local(), el.Format, time.Now())
+	if el.URL != "" {
+		return b.link(text, el.URL)
+	}
+	return b.mrkdwn.literal(text)
+}
+
+// styled renders rich text with its bold, italic, strike and code styles.
+func (b *blockRenderer) styled(text string, style ElementStyle) string {
+	if text == "" {
+		return ""
+	}
+	if style.Code {
+		if !b.markdown() {
+			return "`" + text + "`"
+		}
+		var sb strings.Builder
+		b.mrkdwn.writeCodeSpan(&sb, text)
+		return sb.String()
+	}
+
+	// Keep surrounding whitespace outside the markers so they stay valid.
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	lead := text[:strings.Index(text, trimmed)]
+	trail := text[len(lead)+len(trimmed):]
+
+	out := b.mrkdwn.literal(trimmed)
+	wrap := func(plain, md string) {
+		if b.markdown() {
+			out = md + out + md
+		} else {
+			out = plain + out + plain
+		}
+	}
+	if style.Strike {
+		wrap("~", "~~")
+	}
+	if style.Italic {
+		wrap("_", "_")
+	}
+	if style.Bold {
+		wrap("*", "**")
+	}
+	return lead + out + trail
+}
+
+func (b *blockRenderer) emoji(el BlockElement) string {
+	if el.Unicode != "" {
+		var sb strings.Builder
+		for _, cp := range strings.Split(el.Unicode, "-") {
+			r, err := strconv.ParseInt(cp, 16, 32)
+			if err != nil {
+				sb.Reset()
+				break
+			}
+			sb.WriteRune(rune(r))
+		}
+		if sb.Len() > 0 {
+			return sb.String()
+		}
+	}
+
+	return b.mrkdwn.resolver.renderEmoji(el.Name, b.markdown())
+}
+
+// rawText renders an element inside preformatted text, without styling.
+func (b *blockRenderer) rawText(el BlockElement) string {
+	switch el.Type {
+	case "text":
+		return el.Text.Text
+	case "link":
+		if el.Text.Text != "" {
+			return el.Text.Text
+		}
+		return el.URL
+	case "user":
+		return "@" + b.mrkdwn.resolver.ResolveUser(el.UserID)
+	case "channel":
+		return "#" + b.mrkdwn.resolver.ResolveChannel(el.ChannelID)
+	case "emoji":
+		return ":" + el.Name + ":"
+	}
+	return el.Text.Text
+}
+
+// joinLines joins lines of a single block, using hard breaks in CommonMark so
+// that line structure survives rendering.
+func (b *blockRenderer) joinLines(lines []string) string {
+	if !b.markdown() {
+		return strings.Join(lines, "\n")
+	}
+
+	var sb strings.Builder
+	for i, line := range lines {
+		sb.WriteString(escapeLineStart(line))
+		if i == len(lines)-1 {
+			break
+		}
+		if strings.TrimSpace(line) != "" && strings.TrimSpace(lines[i+1]) != "" {
+			sb.WriteString("\\\n")
+		} else {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,139 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const richTextMessage = `{
+	"type": "message",
+	"user": "U1",
+	"text": "fallback text",
+	"blocks": [
+		{"type": "rich_text", "elements": [
+			{"type": "rich_text_section", "elements": [
+				{"type": "text", "text": "Deploy "},
+				{"type": "text", "text": "finished", "style": {"bold": true}},
+				{"type": "text", "text": " by "},
+				{"type": "user", "user_id": "U1"},
+				{"type": "text", "text": " "},
+				{"type": "emoji", "name": "tada", "unicode": "1f389"},
+				{"type": "text", "text": "\n"}
+			]},
+			{"type": "rich_text_list", "style": "bullet", "elements": [
+				{"type": "rich_text_section", "elements": [{"type": "text", "text": "api"}]},
+				{"type": "rich_text_section", "elements": [
+					{"type": "link", "url": "https://example.com/web", "text": "web"}
+				]}
+			]},
+			{"type": "rich_text_quote", "elements": [{"type": "text", "text": "all green"}]},
+			{"type": "rich_text_preformatted", "elements": [{"type": "text", "text": "make deploy *now*"}]}
+		]}
+	]
+}`
+
+const appMessage = `{
+	"type": "message",
+	"text": "",
+	"blocks": [
+		{"type": "header", "text": {"type": "plain_text", "text": "Incident #42"}},
+		{"type": "section", "text": {"type": "mrkdwn", "text": "*Status:* investigating"},
+		 "fields": [{"type": "mrkdwn", "text": "*Sev:* 2"}]},
+		{"type": "divider"},
+		{"type": "context", "elements": [
+			{"type": "mrkdwn", "text": "Reported by <@U1>"},
+			{"type": "image", "image_url": "https://example.com/a.png", "alt_text": "avatar"}
+		]},
+		{"type": "actions", "elements": [
+			{"type": "button", "text": {"type": "plain_text", "text": "Open"}, "url": "https://example.com/i/42", "style": "primary"},
+			{"type": "button", "text": {"type": "plain_text", "text": "Ack"}, "value": "ack"}
+		]}
+	]
+}`
+
+func decodeMessage(t *testing.T, raw string) Message {
+	t.Helper()
+	var msg Message
+	if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+		t.Fatalf("failed to decode message: %v", err)
+	}
+	return msg
+}
+
+func TestFormatMessageRichText(t *testing.T) {
+	msg := decodeMessage(t, richTextMessage)
+	r := newTestResolver(map[string]string{"U1": "alice"}, nil)
+
+	wantText := "Deploy *finished* by @alice 🎉\n" +
+		"• api\n" +
+		"• web (https://example.com/web)\n" +
+		"> all green\n" +
+		"```make deploy *now*```"
+	if got := r.FormatMessage(msg); got != wantText {
+		t.Errorf("FormatMessage\n got: %q\nwant: %q", got, wantText)
+	}
+
+	wantMarkdown := "Deploy **finished** by @alice 🎉\n\n" +
+		"- api\n" +
+		"- [web](https://example.com/web)\n\n" +
+		"> all green\n\n" +
+		"```\nmake deploy *now*\n```"
+	if got := r.FormatMessageMarkdown(msg); got != wantMarkdown {
+		t.Errorf("FormatMessageMarkdown\n got: %q\nwant: %q", got, wantMarkdown)
+	}
+}
+
+func TestFormatMessageLayoutBlocks(t *testing.T) {
+	msg := decodeMessage(t, appMessage)
+	r := newTestResolver(map[string]string{"U1": "alice"}, nil)
+
+	wantText := "Incident #42\n" +
+		"*Status:* investigating\n" +
+		"*Sev:* 2\n" +
+		"---\n" +
+		"Reported by @alice · [image: avatar] https://example.com/a.png\n" +
+		"Open (https://example.com/i/42) | [Ack]"
+	if got := r.FormatMessage(msg); got != wantText {
+		t.Errorf("FormatMessage\n got: %q\nwant: %q", got, wantText)
+	}
+
+	wantMarkdown := "### Incident #42\n\n" +
+		"**Status:** investigating\n\n" +
+		"**Sev:** 2\n\n" +
+		"---\n\n" +
+		"Reported by @alice · ![avatar](https://example.com/a.png)\n\n" +
+		`[Open](https://example.com/i/42) | \[Ack\]`
+	if got := r.FormatMessageMarkdown(msg); got != wantMarkdown {
+		t.Errorf("FormatMessageMarkdown\n got: %q\nwant: %q", got, wantMarkdown)
+	}
+}
+
+func TestFormatMessageFallsBackToText(t *testing.T) {
+	r := newTestResolver(nil, nil)
+	msg := Message{Text: "hello *world*", Blocks: []Block{{Type: "input"}}}
+
+	if got := r.FormatMessage(msg); got != "hello *world*" {
+		t.Errorf("expected text fallback, got %q", got)
+	}
+	if got := r.FormatMessageMarkdown(msg); got != "hello **world**" {
+		t.Errorf("expected markdown text fallback, got %q", got)
+	}
+}
+
+func TestFormatMessageRichTextGroupsAndDates(t *testing.T) {
+	msg := decodeMessage(t, `{"blocks": [{"type": "rich_text", "elements": [{"type": "rich_text_section", "elements": [
+		{"type": "usergroup", "usergroup_id": "S1"},
+		{"type": "text", "text": " from "},
+		{"type": "date", "timestamp": 1700000000, "format": "{date_num}", "fallback": "Nov 14"},
+		{"type": "text", "text": " to "},
+		{"type": "date", "timestamp": 0, "format": "{date_num}", "fallback": "someday"}
+	]}]}]}`)
+	r := newTestResolver(nil, nil)
+	r.usergroups = map[string]string{"S1": "oncall"}
+
+	want := "@oncall from " + time.Unix(1700000000, 0).Local().Format("2006-01-02") + " to someday"
+	if got := r.FormatMessage(msg); got != want {
+		t.Errorf("FormatMessage\n got: %q\nwant: %q", got, want)
+	}
+}
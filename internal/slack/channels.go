@@ -0,0 +1,202 @@
+package slack
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lox/slack-cli/internal/cache"
+)
+
+// ChannelCacheTTL is how long a workspace's channel list is reused before it
+// is fetched again. Names missing from a cached list trigger a refetch, so new
+// channels resolve straight away.
+const ChannelCacheTTL = 24 * time.Hour
+
+const channelCacheKey = "channels"
+
+var conversationIDPattern = regexp.MustCompile(`^[CGD][A-Z0-9]{6,}$`)
+
+// channelEntry is the part of a conversation kept for name lookups.
+type channelEntry struct {
+	ID       string `json:"id"`
+	Name     string `json:"name,omitempty"`
+	User     string `json:"user,omitempty"`
+	Archived bool   `json:"archived,omitempty"`
+}
+
+// ChannelResolver maps channel names to IDs. It pages through every
+// conversation the user can see, including archived channels and DMs, and
+// keeps the list in an on-disk cache when one is provided.
+type ChannelResolver struct {
+	client  *Client
+	cache   *cache.Cache
+	entries []channelEntry
+	fresh   bool
+}
+
+// NewChannelResolver creates a ChannelResolver. The cache may be nil, in which
+// case the channel list is fetched once per ChannelResolver.
+func NewChannelResolver(client *Client, c *cache.Cache) *ChannelResolver {
+	return &ChannelResolver{client: client, cache: c}
+}
+
+// Resolve returns the ID for a conversation given by ID, name or #name. DMs can
+// be given as @ followed by the other person's user ID. Unknown names return a
+// *ChannelNotFoundError suggesting similar names.
+func (r *ChannelResolver) Resolve(ref string) (string, error) {
+	name := strings.TrimPrefix(strings.TrimSpace(ref), "#")
+	if conversationIDPattern.MatchString(name) {
+		return name, nil
+	}
+
+	if err := r.load(); err != nil {
+		return "", err
+	}
+	if id, ok := r.lookup(name); ok {
+		return id, nil
+	}
+
+	// A cached list may predate the channel, so check with Slack before
+	// giving up.
+	if !r.fresh {
+		if err := r.refresh(); err != nil {
+			return "", err
+		}
+		if id, ok := r.lookup(name); ok {
+			return id, nil
+		}
+	}
+
+	return "", &ChannelNotFoundError{Name: name, Suggestions: r.suggest(name)}
+}
+
+// lookup finds a conversation by exact name, then case-insensitively.
+func (r *ChannelResolver) lookup(name string) (string, bool) {
+	if userID, ok := strings.CutPrefix(name, "@"); ok {
+		for _, e := range r.entries {
+			if e.User != "" && e.User == userID {
+				return e.ID, true
+			}
+		}
+		return "", false
+	}
+
+	for _, e := range r.entries {
+		if e.Name != "" && e.Name == name {
+			return e.ID, true
+		}
+	}
+	for _, e := range r.entries {
+		if e.Name != "" && strings.EqualFold(e.Name, name) {
+			return e.ID, true
+		}
+	}
+	return "", false
+}
+
+// load reads the channel list from the cache, fetching it if the cache is
+// missing or stale.
+func (r *ChannelResolver) load() error {
+	if r.entries != nil {
+		return nil
+	}
+	if r.cache != nil && r.cache.Get(channelCacheKey, ChannelCacheTTL, &r.entries) && r.entries != nil {
+		return nil
+	}
+	return r.refresh()
+}
+
+// refresh fetches every conversation and updates the cache. Failing to write
+// the cache isn't an error, since it only costs a refetch next time.
+func (r *ChannelResolver) refresh() error {
+	if err := r.fetch(); err != nil {
+		return err
+	}
+	if r.cache != nil {
+		_ = r.cache.Set(channelCacheKey, r.entries)
+	}
+	return nil
+}
+
+func (r *ChannelResolver) fetch() error {
+	pager := r.client.ConversationsPager("public_channel,private_channel,mpim,im", 0)
+	channels, err := pager.All()
+	if err != nil {
+		return err
+	}
+
+	entries := make([]channelEntry, 0, len(channels))
+	for _, ch := range channels {
+		entries = append(entries, channelEntry{ID: ch.ID, Name: ch.Name, User: ch.User, Archived: ch.IsArchived})
+	}
+	r.entries, r.fresh = entries, true
+	return nil
+}
+
+// suggest returns up to three channel names close to name, preferring
+// unarchived channels when they are equally close.
+func (r *ChannelResolver) suggest(name string) []string {
+	type candidate struct {
+		name     string
+		distance int
+		archived bool
+	}
+
+	name = strings.ToLower(name)
+	maxDistance := max(2, len(name)/3)
+
+	var candidates []candidate
+	for _, e := range r.entries {
+		if e.Name == "" || e.User != "" || strings.HasPrefix(e.Name, "mpdm-") {
+			continue
+		}
+		candidateName := strings.ToLower(e.Name)
+		distance := levenshtein(name, candidateName)
+		if distance > maxDistance && !(len(name) >= 3 && strings.Contains(candidateName, name)) {
+			continue
+		}
+		candidates = append(candidates, candidate{name: e.Name, distance: distance, archived: e.Archived})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.distance != b.distance {
+			return a.distance < b.distance
+		}
+		if a.archived != b.archived {
+			return !a.archived
+		}
+		return a.name < b.name
+	})
+
+	var suggestions []string
+	for i := 0; i < len(candidates) && i < 3; i++ {
+		suggestions = append(suggestions, candidates[i].name)
+	}
+	return suggestions
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(br)]
+}
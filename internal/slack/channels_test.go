@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lox/slack-cli/internal/cache"
+)
+
+func newChannelListServer(t *testing.T, calls *int, pages ...string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.list" {
+			t.Errorf("expected conversations.list, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("types") != "public_channel,private_channel,mpim,im" {
+			t.Errorf("expected every conversation type, got %q", r.URL.Query().Get("types"))
+		}
+		page := 0
+		if r.URL.Query().Get("cursor") == "next" {
+			page = 1
+		}
+		*calls++
+		_, _ = w.Write([]byte(pages[page]))
+	}))
+}
+
+func TestChannelResolverPagesThroughConversations(t *testing.T) {
+	var calls int
+	server := newChannelListServer(t, &calls,
+		`{"ok":true,"channels":[{"id":"C1","name":"general"}],"response_metadata":{"next_cursor":"next"}}`,
+		`{"ok":true,"channels":[{"id":"C2","name":"old-project","is_archived":true},{"id":"D1","is_im":true,"user":"U9"}]}`,
+	)
+	defer server.Close()
+
+	var slept []time.Duration
+	r := NewChannelResolver(newTestClient(server.URL, &slept), nil)
+
+	tests := map[string]string{
+		"#old-project": "C2",
+		"general":      "C1",
+		"General":      "C1",
+		"@U9":          "D1",
+		"C0123ABCD":    "C0123ABCD",
+	}
+	for ref, want := range tests {
+		got, err := r.Resolve(ref)
+		if err != nil {
+			t.Fatalf("Resolve(%q) returned error: %v", ref, err)
+		}
+		if got != want {
+			t.Fatalf("Resolve(%q) = %q, want %q", ref, got, want)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("expected the list to be fetched once (2 pages), got %d requests", calls)
+	}
+}
+
+func TestChannelResolverUsesCache(t *testing.T) {
+	var calls int
+	server := newChannelListServer(t, &calls,
+		`{"ok":true,"channels":[{"id":"C1","name":"general"}]}`,
+	)
+	defer server.Close()
+
+	var slept []time.Duration
+	client := newTestClient(server.URL, &slept)
+	c := cache.New(t.TempDir())
+
+	if _, err := NewChannelResolver(client, c).Resolve("general"); err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if _, err := NewChannelResolver(client, c).Resolve("general"); err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected the second resolver to use the cache, got %d requests", calls)
+	}
+
+	// A name missing from the cached list is checked with Slack once.
+	_, err := NewChannelResolver(client, c).Resolve("new-channel")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected a refetch for an unknown name, got %d requests", calls)
+	}
+}
+
+func TestChannelResolverSuggestsSimilarNames(t *testing.T) {
+	var calls int
+	server := newChannelListServer(t, &calls,
+		`{"ok":true,"channels":[
+			{"id":"C1","name":"general"},
+			{"id":"C2","name":"generic"},
+			{"id":"C3","name":"random"},
+			{"id":"C4","name":"eng-deploys"},
+			{"id":"G1","name":"mpdm-alice--bob-1","is_mpim":true}
+		]}`,
+	)
+	defer server.Close()
+
+	var slept []time.Duration
+	_, err := NewChannelResolver(newTestClient(server.URL, &slept), nil).Resolve("#genral")
+
+	notFound, ok := err.(*ChannelNotFoundError)
+	if !ok {
+		t.Fatalf("expected a ChannelNotFoundError, got %v", err)
+	}
+	if want := []string{"general"}; !reflect.DeepEqual(notFound.Suggestions, want) {
+		t.Fatalf("expected suggestions %v, got %v", want, notFound.Suggestions)
+	}
+	if want := "no such channel #genral; did you mean #general?"; err.Error() != want {
+		t.Fatalf("expected %q, got %q", want, err.Error())
+	}
+
+	_, err = NewChannelResolver(newTestClient(server.URL, &slept), nil).Resolve("deploys")
+	if notFound, ok := err.(*ChannelNotFoundError); !ok || !reflect.DeepEqual(notFound.Suggestions, []string{"eng-deploys"}) {
+		t.Fatalf("expected substring suggestion, got %v", err)
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"general", "general", 0},
+		{"genral", "general", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Fatalf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
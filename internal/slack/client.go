@@ -10,59 +10,188 @@ import (
 	"time"
 )
 
-const slackAPIBase = "https://slack.com/api"
+const (
+	// DefaultBaseURL is the Slack Web API that clients call unless
+	// WithBaseURL says otherwise.
+	DefaultBaseURL = "https://slack.com/api"
+	// DefaultTimeout is how long an API call may take unless WithTimeout says
+	// otherwise. File transfers aren't limited.
+	DefaultTimeout   = 30 * time.Second
+	defaultUserAgent = "slack-cli"
+)
 
 type Client struct {
 	userToken  string
+	baseURL    string
 	httpClient *http.Client
+	userAgent  string
+	timeout    time.Duration
+	limiter    *rateLimiter
+	maxRetries int
+	sleep      func(time.Duration)
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithBaseURL sends Web API calls to baseURL instead of DefaultBaseURL, such
+// as a proxy or a local mock of Slack.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		if baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/"); baseURL != "" {
+			c.baseURL = baseURL
+		}
+	}
+}
+
+// WithHTTPClient makes requests, including OAuth and file transfers, with hc,
+// for instance to use a custom transport or certificate authorities. Its
+// Timeout is replaced by the one set with WithTimeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		if userAgent != "" {
+			c.userAgent = userAgent
+		}
+	}
+}
+
+// WithTimeout limits how long each API call may take. Zero means no limit.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// NewClient creates a client that calls the Web API with userToken, which
+// may be empty for methods that don't need one, such as oauth.v2.access.
+func NewClient(userToken string, opts ...Option) *Client {
+	c := &Client{
+		userToken:  userToken,
+		baseURL:    DefaultBaseURL,
+		httpClient: http.DefaultClient,
+		userAgent:  defaultUserAgent,
+		timeout:    DefaultTimeout,
+		limiter:    newRateLimiter(),
+		maxRetries: defaultMaxRetries,
+		sleep:      time.Sleep,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// Copy the HTTP client so setting the timeout doesn't change the caller's.
+	httpClient := *c.httpClient
+	httpClient.Timeout = c.timeout
+	c.httpClient = &httpClient
+	return c
 }
 
-func NewClient(userToken string) *Client {
-	return &Client{
-		userToken: userToken,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+// newRequest creates a request carrying the client's user agent.
+func (c *Client) newRequest(httpMethod, target string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(httpMethod, target, body)
+	if err != nil {
+		return nil, err
 	}
+	req.Header.Set("User-Agent", c.userAgent)
+	return req, nil
 }
 
+// request calls a Web API method, pacing calls per Slack's rate tiers and
+// retrying rate-limited (HTTP 429), server (5xx) and network failures.
 func (c *Client) request(method string, params url.Values) ([]byte, error) {
-	req, err := http.NewRequest("GET", slackAPIBase+"/"+method+"?"+params.Encode(), nil)
+	return c.do(http.MethodGet, method, params)
+}
+
+// post calls a Web API method that has side effects, sending params as a
+// form body. Only rate-limited calls are retried, since Slack rejects those
+// before acting on them; retrying anything else could act twice.
+func (c *Client) post(method string, params url.Values) ([]byte, error) {
+	return c.do(http.MethodPost, method, params)
+}
+
+func (c *Client) do(httpMethod, method string, params url.Values) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		if wait := c.limiter.reserve(method); wait > 0 {
+			c.sleep(wait)
+		}
+
+		body, retryDelay, err := c.send(httpMethod, method, params, attempt)
+		if err == nil {
+			return body, nil
+		}
+		if retryDelay < 0 || attempt >= c.maxRetries {
+			return nil, err
+		}
+		if httpMethod != http.MethodGet && !IsRateLimited(err) {
+			return nil, err
+		}
+		c.sleep(retryDelay)
+	}
+}
+
+// send makes a single attempt at a Web API call. On failure it returns how
+// long to wait before retrying, or a negative delay if the error is final.
+func (c *Client) send(httpMethod, method string, params url.Values, attempt int) ([]byte, time.Duration, error) {
+	var req *http.Request
+	var err error
+	if httpMethod == http.MethodGet {
+		req, err = c.newRequest(httpMethod, c.baseURL+"/"+method+"?"+params.Encode(), nil)
+	} else {
+		req, err = c.newRequest(httpMethod, c.baseURL+"/"+method, strings.NewReader(params.Encode()))
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, -1, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authorization", "Bearer "+c.userToken)
+	if c.userToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.userToken)
+	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return nil, backoff(attempt), fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close() //nolint:errcheck
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		delay := retryAfter(resp.Header, backoff(attempt))
+		c.limiter.block(method, delay)
+		return nil, delay, &APIError{Method: method, StatusCode: resp.StatusCode}
+	}
+
+	if resp.StatusCode >= 500 {
+		return nil, backoff(attempt), &APIError{Method: method, StatusCode: resp.StatusCode}
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("slack API returned HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, -1, &APIError{Method: method, StatusCode: resp.StatusCode}
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return nil, backoff(attempt), fmt.Errorf("failed to read response: %w", err)
 	}
 
-	var slackResp struct {
-		OK    bool   `json:"ok"`
-		Error string `json:"error"`
-	}
+	var slackResp apiResponse
 	if err := json.Unmarshal(body, &slackResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, -1, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	if !slackResp.OK {
-		return nil, fmt.Errorf("slack API error: %s", slackResp.Error)
+		return nil, -1, slackResp.apiError(method, resp.StatusCode)
 	}
 
-	return body, nil
+	return body, 0, nil
 }
 
 func (c *Client) AuthTest() (*AuthTestResponse, error) {
@@ -79,52 +208,151 @@ func (c *Client) AuthTest() (*AuthTestResponse, error) {
 	return &result, nil
 }
 
-func (c *Client) GetConversationReplies(channel, threadTS string, limit int) (*RepliesResponse, error) {
-	params := url.Values{}
-	params.Set("channel", channel)
-	params.Set("ts", threadTS)
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
+// HistoryOptions bounds a conversations.history or conversations.replies
+// request. Oldest and Latest are Slack timestamps; either may be empty.
+type HistoryOptions struct {
+	// Limit is the total number of messages to fetch; zero fetches all.
+	Limit int
+	// Oldest and Latest restrict results to messages within this range.
+	Oldest string
+	Latest string
+	// Inclusive includes messages exactly at Oldest or Latest.
+	Inclusive bool
+}
+
+func (o HistoryOptions) apply(params url.Values) {
+	if o.Oldest != "" {
+		params.Set("oldest", o.Oldest)
 	}
+	if o.Latest != "" {
+		params.Set("latest", o.Latest)
+	}
+	if o.Inclusive {
+		params.Set("inclusive", "true")
+	}
+}
+
+// RepliesPager returns a Pager over the messages in a thread, starting with
+// the parent message.
+func (c *Client) RepliesPager(channel, threadTS string, opts HistoryOptions) *Pager[Message] {
+	first := true
+	return NewPager(opts.Limit, func(cursor string, pageSize int) ([]Message, string, error) {
+		params := url.Values{}
+		params.Set("channel", channel)
+		params.Set("ts", threadTS)
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		opts.apply(params)
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("conversations.replies", params)
+		if err != nil {
+			return nil, "", err
+		}
 
-	body, err := c.request("conversations.replies", params)
+		var result RepliesResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse replies response: %w", err)
+		}
+
+		messages := result.Messages
+		// Slack repeats the parent message at the top of every page.
+		if !first && len(messages) > 0 && messages[0].TS == threadTS {
+			messages = messages[1:]
+		}
+		first = false
+
+		return messages, nextCursor(result.HasMore, result.ResponseMetadata), nil
+	})
+}
+
+// GetConversationReplies returns messages from a thread within the bounds of
+// opts, following pagination cursors.
+func (c *Client) GetConversationReplies(channel, threadTS string, opts HistoryOptions) (*RepliesResponse, error) {
+	pager := c.RepliesPager(channel, threadTS, opts)
+	messages, err := pager.All()
 	if err != nil {
 		return nil, err
 	}
 
-	var result RepliesResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse replies response: %w", err)
+	return &RepliesResponse{
+		OK:               true,
+		Messages:         messages,
+		HasMore:          pager.HasMore(),
+		ResponseMetadata: ResponseMetadata{NextCursor: pager.NextCursor()},
+	}, nil
+}
+
+// HistoryPager returns a Pager over a conversation's messages, newest first.
+func (c *Client) HistoryPager(channel string, opts HistoryOptions) *Pager[Message] {
+	return NewPager(opts.Limit, func(cursor string, pageSize int) ([]Message, string, error) {
+		params := url.Values{}
+		params.Set("channel", channel)
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		opts.apply(params)
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("conversations.history", params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result HistoryResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse history response: %w", err)
+		}
+
+		return result.Messages, nextCursor(result.HasMore, result.ResponseMetadata), nil
+	})
+}
+
+// GetConversationHistory returns messages from a conversation within the
+// bounds of opts, newest first, following pagination cursors.
+func (c *Client) GetConversationHistory(channel string, opts HistoryOptions) (*HistoryResponse, error) {
+	pager := c.HistoryPager(channel, opts)
+	messages, err := pager.All()
+	if err != nil {
+		return nil, err
 	}
 
-	return &result, nil
+	return &HistoryResponse{
+		OK:               true,
+		Messages:         messages,
+		HasMore:          pager.HasMore(),
+		ResponseMetadata: ResponseMetadata{NextCursor: pager.NextCursor()},
+	}, nil
 }
 
-func (c *Client) GetConversationHistory(channel string, limit int) (*HistoryResponse, error) {
+func (c *Client) GetConversationInfo(channel string) (*Channel, error) {
 	params := url.Values{}
 	params.Set("channel", channel)
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
-	}
 
-	body, err := c.request("conversations.history", params)
+	body, err := c.request("conversations.info", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result HistoryResponse
+	var result struct {
+		Channel Channel `json:"channel"`
+	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse history response: %w", err)
+		return nil, fmt.Errorf("failed to parse conversation info response: %w", err)
 	}
 
-	return &result, nil
+	return &result.Channel, nil
 }
 
-func (c *Client) GetConversationInfo(channel string) (*Channel, error) {
+// OpenConversation opens a DM with one user, or a group DM with several, and
+// returns it. Slack returns the existing conversation if there is one.
+func (c *Client) OpenConversation(userIDs []string) (*Channel, error) {
 	params := url.Values{}
-	params.Set("channel", channel)
+	params.Set("users", strings.Join(userIDs, ","))
+	params.Set("return_im", "true")
 
-	body, err := c.request("conversations.info", params)
+	body, err := c.post("conversations.open", params)
 	if err != nil {
 		return nil, err
 	}
@@ -133,12 +361,41 @@ func (c *Client) GetConversationInfo(channel string) (*Channel, error) {
 		Channel Channel `json:"channel"`
 	}
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse conversation info response: %w", err)
+		return nil, fmt.Errorf("failed to parse conversation response: %w", err)
 	}
 
 	return &result.Channel, nil
 }
 
+// GetConversationMembers returns the user IDs of every member of a
+// conversation, following pagination cursors.
+func (c *Client) GetConversationMembers(channel string) ([]string, error) {
+	pager := NewPager(0, func(cursor string, pageSize int) ([]string, string, error) {
+		params := url.Values{}
+		params.Set("channel", channel)
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("conversations.members", params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result struct {
+			Members          []string         `json:"members"`
+			ResponseMetadata ResponseMetadata `json:"response_metadata"`
+		}
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse members response: %w", err)
+		}
+
+		return result.Members, result.ResponseMetadata.NextCursor, nil
+	})
+	return pager.All()
+}
+
 func (c *Client) GetUserInfo(userID string) (*User, error) {
 	params := url.Values{}
 	params.Set("user", userID)
@@ -158,14 +415,54 @@ func (c *Client) GetUserInfo(userID string) (*User, error) {
 	return &result.User, nil
 }
 
-func (c *Client) SearchMessages(query string, count int) (*SearchResponse, error) {
+// SearchOptions controls paging and ordering for the search methods.
+type SearchOptions struct {
+	// Count is the number of results per page, up to 100.
+	Count int
+	// Page is the 1-based page to return.
+	Page int
+	// Sort is "score" or "timestamp"; Slack defaults to score.
+	Sort string
+	// Asc sorts in ascending rather than descending order.
+	Asc bool
+	// Highlight marks matched terms in the text with U+E000 and U+E001.
+	Highlight bool
+}
+
+func (c *Client) SearchMessages(query string, opts SearchOptions) (*SearchResponse, error) {
+	return c.search("search.messages", query, opts)
+}
+
+// SearchFiles searches the files shared in the workspace.
+func (c *Client) SearchFiles(query string, opts SearchOptions) (*SearchResponse, error) {
+	return c.search("search.files", query, opts)
+}
+
+// SearchAll searches messages and files at once. Paging applies to both.
+func (c *Client) SearchAll(query string, opts SearchOptions) (*SearchResponse, error) {
+	return c.search("search.all", query, opts)
+}
+
+func (c *Client) search(method, query string, opts SearchOptions) (*SearchResponse, error) {
 	params := url.Values{}
 	params.Set("query", query)
-	if count > 0 {
-		params.Set("count", fmt.Sprintf("%d", count))
+	if opts.Count > 0 {
+		params.Set("count", fmt.Sprintf("%d", opts.Count))
+	}
+	if opts.Page > 0 {
+		params.Set("page", fmt.Sprintf("%d", opts.Page))
+	}
+	if opts.Sort != "" {
+		params.Set("sort", opts.Sort)
+	}
+	if opts.Asc {
+		params.Set("sort_dir", "asc")
+	}
+	if opts.Highlight {
+		params.Set("highlight", "true")
 	}
 
-	body, err := c.request("search.messages", params)
+	body, err := c.request(method, params)
 	if err != nil {
 		return nil, err
 	}
@@ -178,28 +475,50 @@ func (c *Client) SearchMessages(query string, count int) (*SearchResponse, error
 	return &result, nil
 }
 
-func (c *Client) ListConversations(types string, limit int) (*ConversationsResponse, error) {
-	params := url.Values{}
-	if types != "" {
-		params.Set("types", types)
-	} else {
-		params.Set("types", "public_channel,private_channel")
-	}
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
+// ConversationsPager returns a Pager over conversations of the given types.
+// An empty types value lists public and private channels.
+func (c *Client) ConversationsPager(types string, limit int) *Pager[Channel] {
+	if types == "" {
+		types = "public_channel,private_channel"
 	}
 
-	body, err := c.request("conversations.list", params)
+	return NewPager(limit, func(cursor string, pageSize int) ([]Channel, string, error) {
+		params := url.Values{}
+		params.Set("types", types)
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("conversations.list", params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result ConversationsResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse conversations response: %w", err)
+		}
+
+		return result.Channels, result.ResponseMetadata.NextCursor, nil
+	})
+}
+
+// ListConversations returns up to limit conversations, following pagination
+// cursors. A limit of zero lists every conversation.
+func (c *Client) ListConversations(types string, limit int) (*ConversationsResponse, error) {
+	pager := c.ConversationsPager(types, limit)
+	channels, err := pager.All()
 	if err != nil {
 		return nil, err
 	}
 
-	var result ConversationsResponse
-	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse conversations response: %w", err)
-	}
-
-	return &result, nil
+	return &ConversationsResponse{
+		OK:               true,
+		Channels:         channels,
+		HasMore:          pager.HasMore(),
+		ResponseMetadata: ResponseMetadata{NextCursor: pager.NextCursor()},
+	}, nil
 }
 
 func (c *Client) LookupUserByEmail(email string) (*User, error) {
@@ -221,48 +540,288 @@ func (c *Client) LookupUserByEmail(email string) (*User, error) {
 	return &result.User, nil
 }
 
+// UsersPager returns a Pager over the members of the workspace.
+func (c *Client) UsersPager(limit int) *Pager[User] {
+	return NewPager(limit, func(cursor string, pageSize int) ([]User, string, error) {
+		params := url.Values{}
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("users.list", params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result UsersResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse users response: %w", err)
+		}
+
+		return result.Members, result.ResponseMetadata.NextCursor, nil
+	})
+}
+
+// ListUsers returns up to limit workspace members, following pagination
+// cursors. A limit of zero lists every member.
 func (c *Client) ListUsers(limit int) (*UsersResponse, error) {
+	pager := c.UsersPager(limit)
+	members, err := pager.All()
+	if err != nil {
+		return nil, err
+	}
+
+	return &UsersResponse{
+		OK:               true,
+		Members:          members,
+		HasMore:          pager.HasMore(),
+		ResponseMetadata: ResponseMetadata{NextCursor: pager.NextCursor()},
+	}, nil
+}
+
+// PostMessageOptions controls how chat.postMessage posts a message.
+type PostMessageOptions struct {
+	// ThreadTS posts the message as a reply in this thread.
+	ThreadTS string
+	// ReplyBroadcast also shows a thread reply in the channel.
+	ReplyBroadcast bool
+	// Blocks is a JSON array of Block Kit blocks. Text is then used as the
+	// notification fallback.
+	Blocks json.RawMessage
+}
+
+// PostMessage posts a message to a channel as the authenticated user.
+func (c *Client) PostMessage(channel, text string, opts PostMessageOptions) (*PostMessageResponse, error) {
 	params := url.Values{}
-	if limit > 0 {
-		params.Set("limit", fmt.Sprintf("%d", limit))
+	params.Set("channel", channel)
+	if text != "" {
+		params.Set("text", text)
+	}
+	if opts.ThreadTS != "" {
+		params.Set("thread_ts", opts.ThreadTS)
+	}
+	if opts.ReplyBroadcast {
+		params.Set("reply_broadcast", "true")
+	}
+	if len(opts.Blocks) > 0 {
+		params.Set("blocks", string(opts.Blocks))
 	}
 
-	body, err := c.request("users.list", params)
+	body, err := c.post("chat.postMessage", params)
 	if err != nil {
 		return nil, err
 	}
 
-	var result UsersResponse
+	var result PostMessageResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to parse users response: %w", err)
+		return nil, fmt.Errorf("failed to parse chat.postMessage response: %w", err)
 	}
 
 	return &result, nil
 }
 
-// ExchangeOAuthCode exchanges an OAuth authorization code for an access token
-func ExchangeOAuthCode(clientID, clientSecret, code, redirectURI string) (string, error) {
+// GetMessage fetches a single message by timestamp. threadTS is the parent's
+// timestamp for thread replies, which don't appear in channel history, and is
+// otherwise empty or equal to ts.
+func (c *Client) GetMessage(channel, ts, threadTS string) (*Message, error) {
+	opts := HistoryOptions{Limit: 1, Oldest: ts, Latest: ts, Inclusive: true}
+	method := "conversations.history"
+
+	var messages []Message
+	var err error
+	if threadTS != "" && threadTS != ts {
+		method = "conversations.replies"
+		opts.Limit = 2 // Slack returns the parent first
+		messages, err = c.RepliesPager(channel, threadTS, opts).All()
+	} else {
+		messages, err = c.HistoryPager(channel, opts).All()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range messages {
+		if msg.TS == ts {
+			return &msg, nil
+		}
+	}
+	return nil, &APIError{Method: method, Code: "message_not_found"}
+}
+
+// UpdateMessage replaces the text, and optionally the blocks, of a message.
+func (c *Client) UpdateMessage(channel, ts, text string, blocks json.RawMessage) error {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("ts", ts)
+	params.Set("text", text)
+	if len(blocks) > 0 {
+		params.Set("blocks", string(blocks))
+	}
+
+	_, err := c.post("chat.update", params)
+	return err
+}
+
+// DeleteMessage deletes a message.
+func (c *Client) DeleteMessage(channel, ts string) error {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("ts", ts)
+
+	_, err := c.post("chat.delete", params)
+	return err
+}
+
+// AddReaction adds an emoji reaction, given by name without colons, to a
+// message.
+func (c *Client) AddReaction(channel, ts, name string) error {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("timestamp", ts)
+	params.Set("name", name)
+
+	_, err := c.post("reactions.add", params)
+	return err
+}
+
+// RemoveReaction removes one of the user's emoji reactions from a message.
+func (c *Client) RemoveReaction(channel, ts, name string) error {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("timestamp", ts)
+	params.Set("name", name)
+
+	_, err := c.post("reactions.remove", params)
+	return err
+}
+
+// ReactionsPager pages through the items the authenticated user has reacted
+// to, most recent first.
+func (c *Client) ReactionsPager(limit int) *Pager[ReactionItem] {
+	return NewPager(limit, func(cursor string, pageSize int) ([]ReactionItem, string, error) {
+		params := url.Values{}
+		params.Set("limit", fmt.Sprintf("%d", pageSize))
+		if cursor != "" {
+			params.Set("cursor", cursor)
+		}
+
+		body, err := c.request("reactions.list", params)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var result ReactionsResponse
+		if err := json.Unmarshal(body, &result); err != nil {
+			return nil, "", fmt.Errorf("failed to parse reactions response: %w", err)
+		}
+
+		return result.Items, result.ResponseMetadata.NextCursor, nil
+	})
+}
+
+// ListReactions returns up to limit items the authenticated user has reacted
+// to, following pagination cursors. A limit of zero fetches all.
+func (c *Client) ListReactions(limit int) (*ReactionsResponse, error) {
+	pager := c.ReactionsPager(limit)
+	items, err := pager.All()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReactionsResponse{
+		OK:               true,
+		Items:            items,
+		HasMore:          pager.HasMore(),
+		ResponseMetadata: ResponseMetadata{NextCursor: pager.NextCursor()},
+	}, nil
+}
+
+// GetPermalink returns the permalink for a message.
+func (c *Client) GetPermalink(channel, ts string) (string, error) {
+	params := url.Values{}
+	params.Set("channel", channel)
+	params.Set("message_ts", ts)
+
+	body, err := c.request("chat.getPermalink", params)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Permalink string `json:"permalink"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse permalink response: %w", err)
+	}
+
+	return result.Permalink, nil
+}
+
+// ListEmoji returns the workspace's custom emoji, mapping each name to its
+// image URL or, for aliases, "alias:" followed by the name it stands for.
+func (c *Client) ListEmoji() (map[string]string, error) {
+	body, err := c.request("emoji.list", url.Values{})
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Emoji map[string]string `json:"emoji"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse emoji response: %w", err)
+	}
+
+	return result.Emoji, nil
+}
+
+// ListUsergroups returns the workspace's user groups, including disabled ones
+// since old messages may still mention them.
+func (c *Client) ListUsergroups() ([]Usergroup, error) {
+	params := url.Values{}
+	params.Set("include_disabled", "true")
+
+	body, err := c.request("usergroups.list", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Usergroups []Usergroup `json:"usergroups"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse usergroups response: %w", err)
+	}
+
+	return result.Usergroups, nil
+}
+
+// nextCursor returns the cursor for the next page of a history-style response,
+// which signals further pages with has_more as well as a cursor.
+func nextCursor(hasMore bool, meta ResponseMetadata) string {
+	if !hasMore {
+		return ""
+	}
+	return meta.NextCursor
+}
+
+// ExchangeOAuthCode exchanges an OAuth authorization code for an access
+// token. The client needn't have a token of its own.
+func (c *Client) ExchangeOAuthCode(clientID, clientSecret, code, redirectURI string) (string, error) {
 	params := url.Values{}
 	params.Set("client_id", clientID)
 	params.Set("client_secret", clientSecret)
 	params.Set("code", code)
 	params.Set("redirect_uri", redirectURI)
 
-	resp, err := http.PostForm(slackAPIBase+"/oauth.v2.access", params)
+	body, err := c.post("oauth.v2.access", params)
 	if err != nil {
-		return "", fmt.Errorf("failed to exchange code: %w", err)
-	}
-	defer resp.Body.Close() //nolint:errcheck
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response: %w", err)
+		return "", err
 	}
 
 	var result struct {
-		OK          bool   `json:"ok"`
-		Error       string `json:"error"`
-		AuthedUser  struct {
+		AuthedUser struct {
 			AccessToken string `json:"access_token"`
 		} `json:"authed_user"`
 	}
@@ -270,10 +829,6 @@ func ExchangeOAuthCode(clientID, clientSecret, code, redirectURI string) (string
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	if !result.OK {
-		return "", fmt.Errorf("oauth error: %s", result.Error)
-	}
-
 	if result.AuthedUser.AccessToken == "" {
 		return "", fmt.Errorf("no user access token in response")
 	}
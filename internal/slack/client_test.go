@@ -0,0 +1,196 @@
+package slack
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetMessageFindsThreadReply(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.replies" {
+			t.Errorf("expected conversations.replies for a reply, got %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("ts") != "100.000001" || r.URL.Query().Get("oldest") != "200.000002" {
+			t.Errorf("unexpected query %v", r.URL.Query())
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[
+			{"ts":"100.000001","user":"U1","text":"parent"},
+			{"ts":"200.000002","user":"U2","text":"reply"}
+		]}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	msg, err := c.GetMessage("C1", "200.000002", "100.000001")
+	if err != nil {
+		t.Fatalf("GetMessage returned error: %v", err)
+	}
+	if msg.Text != "reply" || msg.User != "U2" {
+		t.Fatalf("expected the reply, got %+v", msg)
+	}
+}
+
+func TestGetMessageNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.history" {
+			t.Errorf("expected conversations.history, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"messages":[]}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	_, err := c.GetMessage("C1", "100.000001", "100.000001")
+	if !IsNotFound(err) {
+		t.Fatalf("expected a not found error, got %v", err)
+	}
+}
+
+func TestDownloadFileSendsTokenAndReportsProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxp-test" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.7"))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	var sb strings.Builder
+	var lastWritten, lastTotal int64
+	file := File{ID: "F1", Mimetype: "application/pdf", URLPrivateDownload: server.URL + "/files-pri/T1-F1/download/report.pdf"}
+	n, err := c.DownloadFile(file, &sb, func(written, total int64) { lastWritten, lastTotal = written, total })
+	if err != nil {
+		t.Fatalf("DownloadFile returned error: %v", err)
+	}
+	if n != 8 || sb.String() != "%PDF-1.7" {
+		t.Fatalf("unexpected download: %d bytes %q", n, sb.String())
+	}
+	if lastWritten != 8 || lastTotal != 8 {
+		t.Fatalf("expected final progress 8/8, got %d/%d", lastWritten, lastTotal)
+	}
+}
+
+func TestDownloadFileDetectsSignInPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html>sign in</html>"))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	_, err := c.DownloadFile(File{ID: "F1", Mimetype: "image/png", URLPrivate: server.URL}, io.Discard, nil)
+	if !IsMissingScope(err) {
+		t.Fatalf("expected a missing scope error, got %v", err)
+	}
+}
+
+func TestUploadFiles(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/files.getUploadURLExternal":
+			if r.FormValue("filename") != "app.log" || r.FormValue("length") != "5" || r.FormValue("snippet_type") != "text" {
+				t.Errorf("unexpected upload URL request: %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"upload_url":"` + server.URL + `/upload/abc","file_id":"F1"}`))
+		case "/upload/abc":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != "oops!" {
+				t.Errorf("unexpected upload body %q", body)
+			}
+			_, _ = w.Write([]byte("OK - 5"))
+		case "/files.completeUploadExternal":
+			if r.FormValue("files") != `[{"id":"F1","title":"Logs"}]` || r.FormValue("channel_id") != "C1" ||
+				r.FormValue("thread_ts") != "1.2" || r.FormValue("initial_comment") != "see logs" {
+				t.Errorf("unexpected complete request: %v", r.Form)
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"files":[{"id":"F1","name":"app.log","permalink":"https://acme.slack.com/files/U1/F1/app.log"}]}`))
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	files, err := c.UploadFiles(
+		[]FileUpload{{Name: "app.log", Title: "Logs", Content: strings.NewReader("oops!"), Size: 5, SnippetType: "text"}},
+		UploadOptions{ChannelID: "C1", ThreadTS: "1.2", InitialComment: "see logs"},
+	)
+	if err != nil {
+		t.Fatalf("UploadFiles returned error: %v", err)
+	}
+	if len(files) != 1 || files[0].Permalink != "https://acme.slack.com/files/U1/F1/app.log" {
+		t.Fatalf("unexpected files %+v", files)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/auth.test" {
+			t.Errorf("expected the base URL's path to be kept, got %s", r.URL.Path)
+		}
+		if got := r.Header.Get("User-Agent"); got != "slack-cli/1.2.3" {
+			t.Errorf("expected the configured user agent, got %q", got)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"user":"alice"}`))
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{}
+	c := NewClient("xoxp-test",
+		WithBaseURL(server.URL+"/api/"),
+		WithHTTPClient(httpClient),
+		WithUserAgent("slack-cli/1.2.3"),
+		WithTimeout(5*time.Second),
+	)
+
+	if _, err := c.AuthTest(); err != nil {
+		t.Fatalf("AuthTest returned error: %v", err)
+	}
+	if c.httpClient.Timeout != 5*time.Second {
+		t.Fatalf("expected a 5s timeout, got %v", c.httpClient.Timeout)
+	}
+	if httpClient.Timeout != 0 {
+		t.Fatalf("expected the caller's HTTP client to be left alone, got timeout %v", httpClient.Timeout)
+	}
+}
+
+func TestExchangeOAuthCodeUsesBaseURLWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth.v2.access" {
+			t.Errorf("expected a POST to oauth.v2.access, got %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "" {
+			t.Errorf("expected no Authorization header, got %q", got)
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != "abc" || r.PostForm.Get("client_id") != "id" {
+			t.Errorf("unexpected form %v (%v)", r.PostForm, err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"authed_user":{"access_token":"xoxp-new"}}`))
+	}))
+	defer server.Close()
+
+	token, err := NewClient("", WithBaseURL(server.URL)).ExchangeOAuthCode("id", "secret", "abc", "http://localhost/callback")
+	if err != nil {
+		t.Fatalf("ExchangeOAuthCode returned error: %v", err)
+	}
+	if token != "xoxp-new" {
+		t.Fatalf("expected xoxp-new, got %q", token)
+	}
+}
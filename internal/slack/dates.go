@@ -0,0 +1,133 @@
+package slack
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// formatDate renders a <!date^...> format string such as "{date_short} at
+// {time}" for t, which should already be in the viewer's timezone. The
+// "pretty" tokens use today, yesterday or tomorrow relative to now. Unknown
+// tokens are left as they are.
+func formatDate(t time.Time, format string, now time.Time) string {
+	now = now.In(t.Location())
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(format, "{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(format[start:], "}")
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(format[:start])
+		if out, ok := dateToken(t, format[start+1:end], now); ok {
+			sb.WriteString(out)
+		} else {
+			sb.WriteString(format[start : end+1])
+		}
+		format = format[end+1:]
+	}
+	sb.WriteString(format)
+	return sb.String()
+}
+
+func dateToken(t time.Time, token string, now time.Time) (string, bool) {
+	switch token {
+	case "date_num":
+		return t.Format("2006-01-02"), true
+	case "date":
+		return t.Format("January ") + ordinal(t.Day()) + t.Format(", 2006"), true
+	case "date_short":
+		return t.Format("Jan 2, 2006"), true
+	case "date_long":
+		return t.Format("Monday, January ") + ordinal(t.Day()) + t.Format(", 2006"), true
+	case "date_pretty", "date_short_pretty", "date_long_pretty":
+		if day := relativeDay(t, now); day != "" {
+			return day, true
+		}
+		return dateToken(t, strings.TrimSuffix(token, "_pretty"), now)
+	case "time":
+		return t.Format("3:04 PM"), true
+	case "time_secs":
+		return t.Format("3:04:05 PM"), true
+	case "ago":
+		return ago(now.Sub(t)), true
+	}
+	return "", false
+}
+
+// relativeDay returns "today", "yesterday" or "tomorrow" if t falls on one of
+// those days, or an empty string.
+func relativeDay(t, now time.Time) string {
+	day := func(t time.Time) time.Time {
+		y, m, d := t.Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+	switch math.Round(day(t).Sub(day(now)).Hours() / 24) {
+	case 0:
+		return "today"
+	case -1:
+		return "yesterday"
+	case 1:
+		return "tomorrow"
+	}
+	return ""
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+// ago describes a duration as "3 minutes ago" or "in 2 days".
+func ago(d time.Duration) string {
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount int
+	var unit string
+	switch {
+	case d < time.Minute:
+		if future {
+			return "in a few seconds"
+		}
+		return "just now"
+	case d < time.Hour:
+		amount, unit = int(d/time.Minute), "minute"
+	case d < 24*time.Hour:
+		amount, unit = int(d/time.Hour), "hour"
+	case d < 30*24*time.Hour:
+		amount, unit = int(d/(24*time.Hour)), "day"
+	case d < 365*24*time.Hour:
+		amount, unit = int(d/(30*24*time.Hour)), "month"
+	default:
+		amount, unit = int(d/(365*24*time.Hour)), "year"
+	}
+	if amount != 1 {
+		unit += "s"
+	}
+
+	if future {
+		return fmt.Sprintf("in %d %s", amount, unit)
+	}
+	return fmt.Sprintf("%d %s ago", amount, unit)
+}
@@ -0,0 +1,44 @@
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	loc := time.FixedZone("AEST", 10*60*60)
+	when := time.Date(2014, 2, 18, 6, 39, 42, 0, loc)
+
+	tests := []struct {
+		format string
+		now    time.Time
+		want   string
+	}{
+		{"{date_num}", when, "2014-02-18"},
+		{"{date}", when, "February 18th, 2014"},
+		{"{date_short} at {time}", when, "Feb 18, 2014 at 6:39 AM"},
+		{"{date_long}", when, "Tuesday, February 18th, 2014"},
+		{"{time_secs}", when, "6:39:42 AM"},
+		{"{date_pretty}", when.Add(2 * time.Hour), "today"},
+		{"{date_short_pretty}", when.Add(24 * time.Hour), "yesterday"},
+		{"{date_long_pretty}", when.Add(-24 * time.Hour), "tomorrow"},
+		{"{date_short_pretty}", when.Add(72 * time.Hour), "Feb 18, 2014"},
+		{"{ago}", when.Add(3 * time.Minute), "3 minutes ago"},
+		{"{ago}", when.Add(-25 * time.Hour), "in 1 day"},
+		{"{unknown} {date_num}", when, "{unknown} 2014-02-18"},
+	}
+
+	for _, tt := range tests {
+		if got := formatDate(when, tt.format, tt.now); got != tt.want {
+			t.Errorf("formatDate(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for n, want := range map[int]string{1: "1st", 2: "2nd", 3: "3rd", 4: "4th", 11: "11th", 12: "12th", 13: "13th", 21: "21st", 22: "22nd", 31: "31st"} {
+		if got := ordinal(n); got != want {
+			t.Errorf("ordinal(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package slack
+
+import (
+	"strings"
+
+	"github.com/enescakir/emoji"
+)
+
+const emojiCacheKey = "emoji"
+
+// maxAliasDepth stops alias chains that loop back on themselves.
+const maxAliasDepth = 10
+
+// CustomEmoji is a workspace's custom emoji, or an alias for another emoji.
+type CustomEmoji struct {
+	Name string
+	// URL is the emoji's image, empty for aliases of standard emoji.
+	URL string
+	// AliasFor is the name this emoji is an alias for, if it is one.
+	AliasFor string
+}
+
+// Emoji resolves an emoji name, following custom aliases. It returns the
+// Unicode emoji for standard emoji (and aliases of them), or the image URL
+// for custom emoji. Skin tone modifiers are dropped.
+func (r *Resolver) Emoji(name string) (unicode, url string, ok bool) {
+	name, _, _ = strings.Cut(name, "::")
+	if rendered := standardEmoji(name); rendered != "" {
+		return rendered, "", true
+	}
+	return resolveEmoji(r.customEmoji(), name)
+}
+
+// resolveEmoji follows a custom emoji's alias chain to an image URL or a
+// standard emoji.
+func resolveEmoji(custom map[string]string, name string) (unicode, url string, ok bool) {
+	for depth := 0; depth < maxAliasDepth; depth++ {
+		value, found := custom[name]
+		if !found {
+			if rendered := standardEmoji(name); rendered != "" {
+				return rendered, "", true
+			}
+			return "", "", false
+		}
+		target, isAlias := strings.CutPrefix(value, "alias:")
+		if !isAlias {
+			return "", value, true
+		}
+		name = target
+	}
+	return "", "", false
+}
+
+// renderEmoji renders an emoji name as Unicode when it has one. Custom emoji
+// become an image link in CommonMark and stay as :name: in plain text, as do
+// unknown names.
+func (r *Resolver) renderEmoji(name string, markdown bool) string {
+	code := ":" + name + ":"
+	unicode, url, ok := r.Emoji(name)
+	switch {
+	case ok && unicode != "":
+		return unicode
+	case ok && markdown:
+		return "![" + markdownEscaper.Replace(code) + "](" + escapeLinkDestination(url) + ")"
+	case markdown:
+		return markdownEscaper.Replace(code)
+	}
+	return code
+}
+
+// CustomEmojiList turns the emoji.list response into a list, resolving each
+// alias's image URL through its alias chain.
+func CustomEmojiList(custom map[string]string) []CustomEmoji {
+	list := make([]CustomEmoji, 0, len(custom))
+	for name, value := range custom {
+		e := CustomEmoji{Name: name, URL: value}
+		if target, isAlias := strings.CutPrefix(value, "alias:"); isAlias {
+			e.URL, e.AliasFor = "", target
+			if _, url, ok := resolveEmoji(custom, target); ok {
+				e.URL = url
+			}
+		}
+		list = append(list, e)
+	}
+	return list
+}
+
+// customEmoji returns the workspace's custom emoji, loading them from the
+// on-disk cache or emoji.list on first use. Failures leave custom emoji
+// unresolved rather than failing rendering.
+func (r *Resolver) customEmoji() map[string]string {
+	r.mu.Lock()
+	custom := r.emoji
+	r.mu.Unlock()
+	if custom != nil {
+		return custom
+	}
+
+	custom = make(map[string]string)
+	if r.cache == nil || !r.cache.Get(emojiCacheKey, UserCacheTTL, &custom) {
+		if list, err := r.client.ListEmoji(); err == nil {
+			custom = list
+			if r.cache != nil {
+				_ = r.cache.Set(emojiCacheKey, custom)
+			}
+		}
+	}
+	if custom == nil {
+		custom = make(map[string]string)
+	}
+
+	r.mu.Lock()
+	r.emoji = custom
+	r.mu.Unlock()
+	return custom
+}
+
+// standardEmoji returns the Unicode emoji for a standard shortcode name, or
+// an empty string.
+func standardEmoji(name string) string {
+	code := ":" + name + ":"
+	if rendered := emoji.Parse(code); rendered != code {
+		return rendered
+	}
+	return ""
+}
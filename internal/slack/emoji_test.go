@@ -0,0 +1,95 @@
+package slack
+
+import (
+	"sort"
+	"testing"
+)
+
+func newEmojiResolver() *Resolver {
+	r := newTestResolver(nil, nil)
+	r.emoji = map[string]string{
+		"shipit":        "https://emoji.slack-edge.com/T1/shipit/abc.png",
+		"squirrel":      "alias:shipit",
+		"ship-it-again": "alias:squirrel",
+		"yay":           "alias:tada",
+		"loop-a":        "alias:loop-b",
+		"loop-b":        "alias:loop-a",
+	}
+	return r
+}
+
+func TestEmojiFollowsAliases(t *testing.T) {
+	r := newEmojiResolver()
+
+	tests := []struct {
+		name    string
+		unicode string
+		url     string
+		ok      bool
+	}{
+		{name: "tada", unicode: "🎉", ok: true},
+		{name: "shipit", url: "https://emoji.slack-edge.com/T1/shipit/abc.png", ok: true},
+		{name: "ship-it-again", url: "https://emoji.slack-edge.com/T1/shipit/abc.png", ok: true},
+		{name: "yay", unicode: "🎉", ok: true},
+		{name: "loop-a"},
+		{name: "unknown"},
+	}
+	for _, tt := range tests {
+		unicode, url, ok := r.Emoji(tt.name)
+		if unicode != tt.unicode || url != tt.url || ok != tt.ok {
+			t.Errorf("Emoji(%q) = %q, %q, %v; want %q, %q, %v", tt.name, unicode, url, ok, tt.unicode, tt.url, tt.ok)
+		}
+	}
+}
+
+func TestFormatCustomEmoji(t *testing.T) {
+	r := newEmojiResolver()
+
+	if got, want := r.FormatText("ship it :squirrel: :yay: :nope:"), "ship it :squirrel: 🎉 :nope:"; got != want {
+		t.Errorf("FormatText\n got: %q\nwant: %q", got, want)
+	}
+	if got, want := r.FormatMarkdown("ship it :shipit:"), `ship it ![:shipit:](https://emoji.slack-edge.com/T1/shipit/abc.png)`; got != want {
+		t.Errorf("FormatMarkdown\n got: %q\nwant: %q", got, want)
+	}
+
+	reactions := []Reaction{{Name: "shipit", Count: 2}, {Name: "yay", Count: 1}}
+	if got, want := r.FormatReactions(reactions, false, false), ":shipit: 2 · 🎉 1"; got != want {
+		t.Errorf("FormatReactions\n got: %q\nwant: %q", got, want)
+	}
+	if got, want := r.FormatEmoji("yay"), "🎉"; got != want {
+		t.Errorf("FormatEmoji(yay) = %q, want %q", got, want)
+	}
+	if got, want := r.FormatEmoji("shipit"), ":shipit:"; got != want {
+		t.Errorf("FormatEmoji(shipit) = %q, want %q", got, want)
+	}
+
+	msg := decodeMessage(t, `{"blocks": [{"type": "rich_text", "elements": [{"type": "rich_text_section", "elements": [
+		{"type": "emoji", "name": "squirrel"}
+	]}]}]}`)
+	if got, want := r.FormatMessageMarkdown(msg), `![:squirrel:](https://emoji.slack-edge.com/T1/shipit/abc.png)`; got != want {
+		t.Errorf("FormatMessageMarkdown\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestCustomEmojiList(t *testing.T) {
+	list := CustomEmojiList(map[string]string{
+		"shipit":   "https://example.com/shipit.png",
+		"squirrel": "alias:shipit",
+		"yay":      "alias:tada",
+	})
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	want := []CustomEmoji{
+		{Name: "shipit", URL: "https://example.com/shipit.png"},
+		{Name: "squirrel", URL: "https://example.com/shipit.png", AliasFor: "shipit"},
+		{Name: "yay", AliasFor: "tada"},
+	}
+	if len(list) != len(want) {
+		t.Fatalf("expected %d emoji, got %+v", len(want), list)
+	}
+	for i := range want {
+		if list[i] != want[i] {
+			t.Errorf("emoji %d = %+v, want %+v", i, list[i], want[i])
+		}
+	}
+}
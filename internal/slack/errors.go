@@ -0,0 +1,132 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError is returned when Slack rejects a Web API call, either with an
+// "ok": false response or a non-2xx HTTP status.
+type APIError struct {
+	// Method is the Web API method that was called, e.g. "conversations.info".
+	Method string
+	// Code is Slack's error code, e.g. "channel_not_found". It is empty when
+	// the call failed at the HTTP level.
+	Code string
+	// Needed and Provided list the scopes involved in a missing_scope error.
+	Needed   string
+	Provided string
+	// Warnings holds any warnings Slack attached to the response.
+	Warnings []string
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("slack API returned HTTP %d: %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+
+	msg := "slack API error: " + e.Code
+	if e.Needed != "" {
+		msg += fmt.Sprintf(" (needed: %s, provided: %s)", e.Needed, e.Provided)
+	}
+	return msg
+}
+
+// ChannelNotFoundError is returned when a channel name doesn't match any
+// conversation the user can see.
+type ChannelNotFoundError struct {
+	Name string
+	// Suggestions lists similarly named channels, closest first.
+	Suggestions []string
+}
+
+func (e *ChannelNotFoundError) Error() string {
+	msg := fmt.Sprintf("no such channel #%s", e.Name)
+	if len(e.Suggestions) > 0 {
+		msg += "; did you mean #" + strings.Join(e.Suggestions, ", #") + "?"
+	}
+	return msg
+}
+
+var notFoundCodes = map[string]bool{
+	"channel_not_found": true,
+	"file_not_found":    true,
+	"message_not_found": true,
+	"not_found":         true,
+	"thread_not_found":  true,
+	"user_not_found":    true,
+	"users_not_found":   true,
+}
+
+var revokedTokenCodes = map[string]bool{
+	"account_inactive": true,
+	"invalid_auth":     true,
+	"not_authed":       true,
+	"token_expired":    true,
+	"token_revoked":    true,
+}
+
+// IsNotFound reports whether err is a Slack error for a missing channel,
+// user, message, thread or file, or a channel name that didn't resolve.
+func IsNotFound(err error) bool {
+	if errors.As(err, new(*ChannelNotFoundError)) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && notFoundCodes[apiErr.Code]
+}
+
+// IsMissingScope reports whether err is a Slack error caused by the token
+// lacking a required OAuth scope.
+func IsMissingScope(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == "missing_scope"
+}
+
+// IsRateLimited reports whether err is a Slack rate limit error.
+func IsRateLimited(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.Code == "ratelimited"
+}
+
+// IsTokenRevoked reports whether err is a Slack error caused by a revoked,
+// expired or otherwise invalid token.
+func IsTokenRevoked(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && revokedTokenCodes[apiErr.Code]
+}
+
+// apiResponse is the envelope shared by every Web API response.
+type apiResponse struct {
+	OK               bool   `json:"ok"`
+	Error            string `json:"error"`
+	Needed           string `json:"needed"`
+	Provided         string `json:"provided"`
+	Warning          string `json:"warning"`
+	ResponseMetadata struct {
+		Warnings []string `json:"warnings"`
+	} `json:"response_metadata"`
+}
+
+func (r *apiResponse) apiError(method string, statusCode int) *APIError {
+	warnings := r.ResponseMetadata.Warnings
+	if len(warnings) == 0 && r.Warning != "" {
+		warnings = strings.Split(r.Warning, ",")
+	}
+
+	return &APIError{
+		Method:     method,
+		Code:       r.Error,
+		Needed:     r.Needed,
+		Provided:   r.Provided,
+		Warnings:   warnings,
+		StatusCode: statusCode,
+	}
+}
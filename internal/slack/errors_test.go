@@ -0,0 +1,73 @@
+package slack
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRequestReturnsTypedAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":false,"error":"missing_scope","needed":"chat:write","provided":"search:read","warning":"superfluous_charset"}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	_, err := c.request("chat.postMessage", url.Values{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T: %v", err, err)
+	}
+	if apiErr.Method != "chat.postMessage" || apiErr.Code != "missing_scope" {
+		t.Fatalf("unexpected method/code: %q/%q", apiErr.Method, apiErr.Code)
+	}
+	if apiErr.Needed != "chat:write" || apiErr.Provided != "search:read" {
+		t.Fatalf("unexpected scopes: needed %q provided %q", apiErr.Needed, apiErr.Provided)
+	}
+	if len(apiErr.Warnings) != 1 || apiErr.Warnings[0] != "superfluous_charset" {
+		t.Fatalf("unexpected warnings: %v", apiErr.Warnings)
+	}
+	if apiErr.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", apiErr.StatusCode)
+	}
+	if !IsMissingScope(err) || IsNotFound(err) {
+		t.Fatalf("expected missing scope classification")
+	}
+}
+
+func TestAPIErrorMessage(t *testing.T) {
+	tests := []struct {
+		err  *APIError
+		want string
+	}{
+		{err: &APIError{Code: "channel_not_found"}, want: "slack API error: channel_not_found"},
+		{err: &APIError{Code: "missing_scope", Needed: "chat:write", Provided: "search:read"}, want: "slack API error: missing_scope (needed: chat:write, provided: search:read)"},
+		{err: &APIError{StatusCode: http.StatusBadGateway}, want: "slack API returned HTTP 502: Bad Gateway"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("Error() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestErrorClassifiers(t *testing.T) {
+	if !IsNotFound(&APIError{Code: "user_not_found"}) {
+		t.Errorf("expected user_not_found to be not found")
+	}
+	if !IsTokenRevoked(&APIError{Code: "token_revoked"}) {
+		t.Errorf("expected token_revoked to be revoked")
+	}
+	if !IsRateLimited(&APIError{Code: "ratelimited"}) {
+		t.Errorf("expected ratelimited code to be rate limited")
+	}
+	if IsNotFound(errors.New("slack API error: channel_not_found")) {
+		t.Errorf("expected untyped errors not to match")
+	}
+}
@@ -0,0 +1,213 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Conversations returns the IDs of every conversation the file is shared in.
+func (f File) Conversations() []string {
+	ids := append([]string{}, f.Channels...)
+	ids = append(ids, f.Groups...)
+	return append(ids, f.IMs...)
+}
+
+// GetFileInfo returns a file's metadata, including its private download URL.
+func (c *Client) GetFileInfo(fileID string) (*File, error) {
+	params := url.Values{}
+	params.Set("file", fileID)
+
+	body, err := c.request("files.info", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		File File `json:"file"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse file response: %w", err)
+	}
+
+	return &result.File, nil
+}
+
+// DownloadFile streams a private file to w using the user token, calling
+// progress, if set, as bytes arrive. total is -1 when the size is unknown.
+func (c *Client) DownloadFile(file File, w io.Writer, progress func(written, total int64)) (int64, error) {
+	fileURL := file.URLPrivateDownload
+	if fileURL == "" {
+		fileURL = file.URLPrivate
+	}
+	if fileURL == "" {
+		return 0, fmt.Errorf("file %s has no download URL", file.ID)
+	}
+
+	req, err := c.newRequest(http.MethodGet, fileURL, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.userToken)
+
+	// Large files can take longer than the API timeout to transfer.
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return 0, &APIError{Method: "files.download", StatusCode: resp.StatusCode}
+	}
+	// Slack serves its sign-in page instead of the file when the token can't
+	// read it.
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/html") && !strings.HasPrefix(file.Mimetype, "text/html") {
+		return 0, &APIError{Method: "files.download", Code: "missing_scope", Needed: "files:read", StatusCode: resp.StatusCode}
+	}
+
+	total := resp.ContentLength
+	if total < 0 && file.Size > 0 {
+		total = int64(file.Size)
+	}
+
+	var written int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := w.Write(buf[:n]); err != nil {
+				return written, fmt.Errorf("failed to write file: %w", err)
+			}
+			written += int64(n)
+			if progress != nil {
+				progress(written, total)
+			}
+		}
+		if readErr == io.EOF {
+			return written, nil
+		}
+		if readErr != nil {
+			return written, fmt.Errorf("failed to download file: %w", readErr)
+		}
+	}
+}
+
+// FileUpload is a file to upload with UploadFiles.
+type FileUpload struct {
+	// Name is the file name shown in Slack.
+	Name  string
+	Title string
+	// Content is read once; Size must match the number of bytes it yields.
+	Content io.Reader
+	Size    int64
+	// SnippetType uploads text as a snippet with this syntax type, e.g. "go".
+	SnippetType string
+}
+
+// UploadOptions controls where UploadFiles shares files.
+type UploadOptions struct {
+	ChannelID string
+	// ThreadTS shares the files as a reply in this thread.
+	ThreadTS string
+	// InitialComment is posted as a message alongside the files.
+	InitialComment string
+}
+
+// UploadFiles uploads files and shares them in a single message, using the
+// files.getUploadURLExternal and files.completeUploadExternal flow.
+func (c *Client) UploadFiles(uploads []FileUpload, opts UploadOptions) ([]File, error) {
+	type completedFile struct {
+		ID    string `json:"id"`
+		Title string `json:"title,omitempty"`
+	}
+
+	var completed []completedFile
+	for _, upload := range uploads {
+		params := url.Values{}
+		params.Set("filename", upload.Name)
+		params.Set("length", fmt.Sprintf("%d", upload.Size))
+		if upload.SnippetType != "" {
+			params.Set("snippet_type", upload.SnippetType)
+		}
+
+		body, err := c.post("files.getUploadURLExternal", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var target struct {
+			UploadURL string `json:"upload_url"`
+			FileID    string `json:"file_id"`
+		}
+		if err := json.Unmarshal(body, &target); err != nil {
+			return nil, fmt.Errorf("failed to parse upload URL response: %w", err)
+		}
+
+		if err := c.uploadContent(target.UploadURL, upload); err != nil {
+			return nil, err
+		}
+		completed = append(completed, completedFile{ID: target.FileID, Title: upload.Title})
+	}
+
+	files, err := json.Marshal(completed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode files: %w", err)
+	}
+
+	params := url.Values{}
+	params.Set("files", string(files))
+	if opts.ChannelID != "" {
+		params.Set("channel_id", opts.ChannelID)
+	}
+	if opts.ThreadTS != "" {
+		params.Set("thread_ts", opts.ThreadTS)
+	}
+	if opts.InitialComment != "" {
+		params.Set("initial_comment", opts.InitialComment)
+	}
+
+	body, err := c.post("files.completeUploadExternal", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Files []File `json:"files"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse upload response: %w", err)
+	}
+
+	return result.Files, nil
+}
+
+// uploadContent sends a file's bytes to the upload URL Slack handed out.
+func (c *Client) uploadContent(uploadURL string, upload FileUpload) error {
+	req, err := c.newRequest(http.MethodPost, uploadURL, upload.Content)
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.ContentLength = upload.Size
+	req.Header.Set("Content-Type", "application/octet-stream")
+
+	httpClient := *c.httpClient
+	httpClient.Timeout = 0
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", upload.Name, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &APIError{Method: "files.upload", StatusCode: resp.StatusCode}
+	}
+	return nil
+}
@@ -0,0 +1,271 @@
+package slack
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	fencePattern       = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})")
+	atxHeadingPattern  = regexp.MustCompile(`^ {0,3}#{1,6}(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	quotePattern       = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	bulletItemPattern  = regexp.MustCompile(`^(\s*)[-*+][ \t]+(.*)$`)
+	orderedItemPattern = regexp.MustCompile(`^(\s*)(\d{1,9})[.)][ \t]+(.*)$`)
+	autolinkPattern    = regexp.MustCompile(`^<([a-zA-Z][a-zA-Z0-9+.-]{1,31}:[^<>\s]*)>`)
+	mrkdwnEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+const asciiPunctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// MarkdownToMrkdwn converts CommonMark to Slack mrkdwn for posting. Bold,
+// italic, strikethrough, code, links, headings, lists and quotes are
+// translated, and &, < and > are escaped as Slack requires. Line breaks are
+// kept as they are, since Slack shows them rather than reflowing paragraphs.
+func MarkdownToMrkdwn(md string) string {
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		m := fencePattern.FindStringSubmatch(lines[i])
+		if m == nil {
+			out = append(out, markdownLine(lines[i]))
+			continue
+		}
+
+		var code []string
+		for i++; i < len(lines); i++ {
+			if strings.HasPrefix(strings.TrimLeft(lines[i], " "), m[1]) {
+				break
+			}
+			code = append(code, lines[i])
+		}
+		out = append(out, "```\n"+mrkdwnEscaper.Replace(strings.Join(code, "\n"))+"\n```")
+	}
+
+	return strings.Join(out, "\n")
+}
+
+// markdownLine converts a single line outside fenced code.
+func markdownLine(line string) string {
+	if thematicBreakPattern.MatchString(line) && !strings.Contains(line, "=") {
+		return "---"
+	}
+	if m := atxHeadingPattern.FindStringSubmatch(line); m != nil {
+		if m[1] == "" {
+			return ""
+		}
+		return "*" + markdownInline(m[1]) + "*"
+	}
+	if m := quotePattern.FindStringSubmatch(line); m != nil {
+		return ">" + strings.TrimRight(" "+markdownLine(m[1]), " ")
+	}
+	if m := bulletItemPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + "• " + markdownInline(m[2])
+	}
+	if m := orderedItemPattern.FindStringSubmatch(line); m != nil {
+		return m[1] + m[2] + ". " + markdownInline(m[3])
+	}
+
+	// Hard breaks are implicit in mrkdwn.
+	if strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`) {
+		line = strings.TrimSuffix(line, `\`)
+	}
+	return markdownInline(strings.TrimRight(line, " "))
+}
+
+// markdownInline converts CommonMark inline syntax within a line.
+func markdownInline(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch c {
+		case '\\':
+			if i+1 < len(text) && strings.IndexByte(asciiPunctuation, text[i+1]) >= 0 {
+				sb.WriteString(mrkdwnEscaper.Replace(text[i+1 : i+2]))
+				i += 2
+				continue
+			}
+		case '`':
+			n := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+			fence := text[i : i+n]
+			end := strings.Index(text[i+n:], fence)
+			if end < 0 {
+				sb.WriteString(fence)
+				i += n
+				continue
+			}
+			code := text[i+n : i+n+end]
+			if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+				code = code[1 : len(code)-1]
+			}
+			sb.WriteString("`" + mrkdwnEscaper.Replace(code) + "`")
+			i += n + end + n
+			continue
+		case '!', '[':
+			start := i
+			if c == '!' {
+				start++
+			}
+			if label, dest, n, ok := parseInlineLink(text[start:]); ok {
+				sb.WriteString(mrkdwnLink(label, dest))
+				i = start + n
+				continue
+			}
+		case '<':
+			if m := autolinkPattern.FindStringSubmatch(text[i:]); m != nil {
+				sb.WriteString("<" + mrkdwnEscaper.Replace(m[1]) + ">")
+				i += len(m[0])
+				continue
+			}
+		case '*', '_', '~':
+			if out, n := markdownEmphasis(text, i); n > 0 {
+				sb.WriteString(out)
+				i += n
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		sb.WriteString(mrkdwnEscaper.Replace(text[i : i+size]))
+		i += size
+	}
+
+	return sb.String()
+}
+
+// markdownEmphasis converts a strong, emphasis or strikethrough run opened at
+// start, returning the mrkdwn and the number of bytes consumed, or zero if
+// the delimiter doesn't open a run.
+func markdownEmphasis(text string, start int) (string, int) {
+	c := text[start]
+	doubled := start+1 < len(text) && text[start+1] == c
+
+	var delim, marker string
+	switch {
+	case doubled && c == '~':
+		delim, marker = "~~", "~"
+	case doubled:
+		delim, marker = string([]byte{c, c}), "*"
+	case c == '~':
+		return "", 0
+	default:
+		delim, marker = string(c), "_"
+	}
+
+	end := emphasisEnd(text, start, delim)
+	if end < 0 {
+		return "", 0
+	}
+	inner := markdownInline(text[start+len(delim) : end])
+	return marker + inner + marker, end + len(delim) - start
+}
+
+// emphasisEnd returns the index of the delimiter closing a run opened at
+// start, or -1. Like CommonMark, the delimiters must hug the enclosed text,
+// and underscores don't open or close within words.
+func emphasisEnd(text string, start int, delim string) int {
+	open := start + len(delim)
+	if open >= len(text) {
+		return -1
+	}
+	if next, _ := utf8.DecodeRuneInString(text[open:]); unicode.IsSpace(next) {
+		return -1
+	}
+	if delim[0] == '_' && start > 0 {
+		if prev, _ := utf8.DecodeLastRuneInString(text[:start]); isWordRune(prev) {
+			return -1
+		}
+	}
+
+	for j := open + 1; j+len(delim) <= len(text); j++ {
+		if text[j] == '`' {
+			if end := strings.IndexByte(text[j+1:], '`'); end >= 0 {
+				j += end + 1
+			}
+			continue
+		}
+		if !strings.HasPrefix(text[j:], delim) {
+			continue
+		}
+		if prev, _ := utf8.DecodeLastRuneInString(text[:j]); unicode.IsSpace(prev) {
+			continue
+		}
+		after := j + len(delim)
+		if after < len(text) && text[after] == delim[0] {
+			if len(delim) == 1 {
+				// Part of a longer run that closes something else.
+				j = after
+				continue
+			}
+			// ***both*** closes the strong run one character later.
+			j++
+			after++
+		}
+		if delim[0] == '_' && after < len(text) {
+			if next, _ := utf8.DecodeRuneInString(text[after:]); isWordRune(next) {
+				continue
+			}
+		}
+		return j
+	}
+	return -1
+}
+
+// parseInlineLink parses a [label](destination "title") link at the start of
+// text, returning its label, destination and length.
+func parseInlineLink(text string) (label, dest string, n int, ok bool) {
+	if !strings.HasPrefix(text, "[") {
+		return "", "", 0, false
+	}
+
+	depth := 0
+	closeLabel := -1
+	for i := 0; i < len(text) && closeLabel < 0; i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				closeLabel = i
+			}
+		}
+	}
+	if closeLabel < 0 || !strings.HasPrefix(text[closeLabel+1:], "(") {
+		return "", "", 0, false
+	}
+
+	depth = 0
+	for i := closeLabel + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			inside := strings.TrimSpace(text[closeLabel+2 : i])
+			dest, _, _ = strings.Cut(inside, " ")
+			dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+			return text[1:closeLabel], dest, i + 1, true
+		}
+	}
+	return "", "", 0, false
+}
+
+func mrkdwnLink(label, dest string) string {
+	dest = mrkdwnEscaper.Replace(dest)
+	if label == "" || label == dest {
+		return "<" + dest + ">"
+	}
+	label = strings.ReplaceAll(markdownInline(label), "|", "¦")
+	return "<" + dest + "|" + label + ">"
+}
@@ -0,0 +1,44 @@
+package slack
+
+import "testing"
+
+func TestMarkdownToMrkdwn(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "plain text", input: "hello world", want: "hello world"},
+		{name: "strong", input: "this is **important**", want: "this is *important*"},
+		{name: "emphasis", input: "*maybe* or _maybe_", want: "_maybe_ or _maybe_"},
+		{name: "strong emphasis", input: "***both***", want: "*_both_*"},
+		{name: "strikethrough", input: "~~never~~", want: "~never~"},
+		{name: "intraword underscores", input: "snake_case_name", want: "snake_case_name"},
+		{name: "special characters escaped", input: "a < b && c > d", want: "a &lt; b &amp;&amp; c &gt; d"},
+		{name: "backslash escapes", input: `\*not bold\*`, want: "*not bold*"},
+		{name: "code span untouched", input: "run `**x** <y>` now", want: "run `**x** &lt;y&gt;` now"},
+		{name: "link", input: "see [the docs](https://example.com/a?b=1&c=2 \"Docs\")", want: "see <https://example.com/a?b=1&amp;c=2|the docs>"},
+		{name: "link with formatted label", input: "[**PR** #12](https://github.com/x/y/pull/12)", want: "<https://github.com/x/y/pull/12|*PR* #12>"},
+		{name: "autolink", input: "<https://example.com>", want: "<https://example.com>"},
+		{name: "image", input: "![graph](https://example.com/g.png)", want: "<https://example.com/g.png|graph>"},
+		{name: "heading", input: "## Release notes ##", want: "*Release notes*"},
+		{name: "bullet list", input: "- one\n  * two", want: "• one\n  • two"},
+		{name: "ordered list", input: "1) first\n2. second", want: "1. first\n2. second"},
+		{name: "quote", input: "> quoted **text**\n>", want: "> quoted *text*\n>"},
+		{name: "thematic break", input: "***", want: "---"},
+		{name: "hard break", input: "line one\\\nline two  ", want: "line one\nline two"},
+		{
+			name:  "fenced code block",
+			input: "```go\nif a < b {\n  **x**\n}\n```\nafter",
+			want:  "```\nif a &lt; b {\n  **x**\n}\n```\nafter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToMrkdwn(tt.input); got != tt.want {
+				t.Errorf("MarkdownToMrkdwn(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
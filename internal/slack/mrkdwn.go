@@ -0,0 +1,404 @@
+package slack
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+var (
+	emojiShortcodePattern = regexp.MustCompile(`^:[a-z0-9_+'-]+:`)
+	thematicBreakPattern  = regexp.MustCompile(`^\s*([-*_=])(\s*[-*_=]){2,}\s*$`)
+	markdownEscaper       = strings.NewReplacer(
+		`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `~`, `\~`,
+		`[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `&`, `\&`,
+	)
+	entityDecoder = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// EscapeMarkdown escapes text so that CommonMark renders it literally.
+func EscapeMarkdown(text string) string {
+	return markdownEscaper.Replace(text)
+}
+
+// mrkdwnRenderer converts Slack mrkdwn to plain text or CommonMark. Plain text
+// keeps Slack's formatting characters but resolves mentions, links, emoji and
+// entities; CommonMark additionally translates bold, italic, strikethrough,
+// code and quotes, and escapes anything CommonMark would otherwise interpret.
+// Code spans and blocks are passed through untouched in both modes.
+type mrkdwnRenderer struct {
+	resolver *Resolver
+	markdown bool
+}
+
+func (m *mrkdwnRenderer) render(text string) string {
+	var sb strings.Builder
+
+	rest := text
+	for {
+		start := strings.Index(rest, "```")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(rest[start+3:], "```")
+		if end == -1 {
+			break
+		}
+		end += start + 3
+
+		m.writeText(&sb, rest[:start])
+		m.writeCodeBlock(&sb, rest[start+3:end])
+		rest = rest[end+3:]
+	}
+	m.writeText(&sb, rest)
+
+	return sb.String()
+}
+
+func (m *mrkdwnRenderer) writeCodeBlock(sb *strings.Builder, code string) {
+	code = entityDecoder.Replace(code)
+	if !m.markdown {
+		sb.WriteString("```" + code + "```")
+		return
+	}
+
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if sb.Len() > 0 && !strings.HasSuffix(sb.String(), "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fence + "\n" + strings.Trim(code, "\n") + "\n" + fence + "\n")
+}
+
+// writeText renders a run of mrkdwn outside code blocks.
+func (m *mrkdwnRenderer) writeText(sb *strings.Builder, text string) {
+	if !m.markdown {
+		sb.WriteString(m.inline(text))
+		return
+	}
+
+	if strings.HasSuffix(sb.String(), "\n") {
+		// The preceding code block already ended the line.
+		text = strings.TrimPrefix(strings.TrimLeft(text, " "), "\n")
+	}
+	if text == "" {
+		return
+	}
+
+	lines := strings.Split(text, "\n")
+	quoteRest := false
+	quoted := make([]bool, len(lines))
+	for i, line := range lines {
+		if quoteRest {
+			quoted[i] = true
+			continue
+		}
+		if after, ok := strings.CutPrefix(line, "&gt;&gt;&gt;"); ok {
+			lines[i] = after
+			quoted[i] = true
+			quoteRest = true
+			continue
+		}
+		if after, ok := strings.CutPrefix(line, "&gt;"); ok {
+			lines[i] = after
+			quoted[i] = true
+		}
+	}
+
+	for i, line := range lines {
+		if quoted[i] {
+			line = strings.TrimPrefix(line, " ")
+		}
+		converted := m.inline(line)
+		if !quoted[i] {
+			converted = escapeLineStart(converted)
+		}
+
+		if quoted[i] {
+			sb.WriteString("> ")
+		}
+		sb.WriteString(converted)
+
+		if i == len(lines)-1 {
+			break
+		}
+
+		next := lines[i+1]
+		switch {
+		case quoted[i] && !quoted[i+1]:
+			// End the blockquote so the next line isn't a lazy continuation.
+			sb.WriteString("\n\n")
+		case strings.TrimSpace(line) != "" && strings.TrimSpace(next) != "" && quoted[i] == quoted[i+1]:
+			// Slack treats single newlines as line breaks.
+			sb.WriteString("\\\n")
+		default:
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// escapeLineStart escapes characters that would start a heading or
+// thematic break at the beginning of a CommonMark line.
+func escapeLineStart(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	indent := line[:len(line)-len(trimmed)]
+	if strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "+ ") || thematicBreakPattern.MatchString(trimmed) {
+		return indent + `\` + trimmed
+	}
+	return line
+}
+
+// inline renders mrkdwn within a single block: code spans, mentions, links,
+// entities, emoji and bold/italic/strikethrough.
+func (m *mrkdwnRenderer) inline(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch c {
+		case '`':
+			if end := strings.IndexAny(text[i+1:], "`\n"); end > 0 && text[i+1+end] == '`' {
+				m.writeCodeSpan(&sb, text[i+1:i+1+end])
+				i += end + 2
+				continue
+			}
+		case '<':
+			if end := strings.IndexAny(text[i+1:], ">\n"); end > 0 && text[i+1+end] == '>' {
+				if out, ok := m.token(text[i+1 : i+1+end]); ok {
+					sb.WriteString(out)
+					i += end + 2
+					continue
+				}
+			}
+		case '&':
+			if decoded, n := decodeEntity(text[i:]); n > 0 {
+				sb.WriteString(m.literal(decoded))
+				i += n
+				continue
+			}
+		case ':':
+			if code := emojiShortcodePattern.FindString(text[i:]); code != "" {
+				if rendered := m.emoji(code); rendered != "" {
+					sb.WriteString(rendered)
+					i += len(code)
+					continue
+				}
+			}
+		case '*', '_', '~':
+			if end := formattingEnd(text, i); end > 0 {
+				inner := m.inline(text[i+1 : end])
+				if m.markdown {
+					marker := map[byte]string{'*': "**", '_': "_", '~': "~~"}[c]
+					sb.WriteString(marker + inner + marker)
+				} else {
+					sb.WriteString(string(c) + inner + string(c))
+				}
+				i = end + 1
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(text[i:])
+		sb.WriteString(m.literal(text[i : i+size]))
+		i += size
+	}
+
+	return sb.String()
+}
+
+func (m *mrkdwnRenderer) writeCodeSpan(sb *strings.Builder, code string) {
+	code = entityDecoder.Replace(code)
+	if !m.markdown {
+		sb.WriteString("`" + code + "`")
+		return
+	}
+
+	fence := "`"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	if strings.HasPrefix(code, "`") || strings.HasSuffix(code, "`") {
+		code = " " + code + " "
+	}
+	sb.WriteString(fence + code + fence)
+}
+
+// token renders the contents of a <...> sequence.
+func (m *mrkdwnRenderer) token(content string) (string, bool) {
+	target, label, _ := strings.Cut(content, "|")
+
+	switch {
+	case strings.HasPrefix(target, "@"):
+		userID := target[1:]
+		displayName := m.resolver.ResolveUser(userID)
+		if displayName == userID && label != "" {
+			displayName = label
+		}
+		return m.literal("@" + displayName), true
+
+	case strings.HasPrefix(target, "#"):
+		channelName := label
+		if channelName == "" {
+			channelName = m.resolver.ResolveChannel(target[1:])
+		}
+		return m.literal("#" + channelName), true
+
+	case strings.HasPrefix(target, "!"):
+		return m.special(target[1:], label), true
+
+	case strings.Contains(target, "://"):
+		label = entityDecoder.Replace(label)
+		target = entityDecoder.Replace(target)
+		if !m.markdown {
+			if label == "" {
+				return target, true
+			}
+			return label + " (" + target + ")", true
+		}
+		if label == "" || label == target {
+			return "<" + target + ">", true
+		}
+		return "[" + markdownEscaper.Replace(label) + "](" + escapeLinkDestination(target) + ")", true
+
+	case strings.HasPrefix(target, "mailto:"):
+		address := entityDecoder.Replace(strings.TrimPrefix(target, "mailto:"))
+		label = entityDecoder.Replace(label)
+		if label == "" {
+			label = address
+		}
+		if !m.markdown {
+			if label == address {
+				return address, true
+			}
+			return label + " (" + address + ")", true
+		}
+		return "[" + markdownEscaper.Replace(label) + "](" + escapeLinkDestination("mailto:"+address) + ")", true
+	}
+
+	return "", false
+}
+
+// special renders a <!...> sequence: a user group or broadcast mention, or a
+// date. Unknown sequences show their label.
+func (m *mrkdwnRenderer) special(target, label string) string {
+	command, arg, _ := strings.Cut(target, "^")
+
+	switch command {
+	case "here", "channel", "everyone":
+		return m.literal("@" + command)
+
+	case "subteam":
+		if handle, ok := m.resolver.ResolveUsergroup(arg); ok {
+			return m.literal("@" + handle)
+		}
+		if label != "" {
+			return m.literal("@" + strings.TrimPrefix(label, "@"))
+		}
+		return m.literal("@" + arg)
+
+	case "date":
+		ts, rest, _ := strings.Cut(arg, "^")
+		format, link, _ := strings.Cut(rest, "^")
+		sec, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil || format == "" {
+			return m.literal(entityDecoder.Replace(label))
+		}
+		text := formatDate(time.Unix(sec, 0).Local(), entityDecoder.Replace(format), time.Now())
+		if out, ok := m.token(link + "|" + text); ok && link != "" {
+			return out
+		}
+		return m.literal(text)
+	}
+
+	if label != "" {
+		return m.literal(entityDecoder.Replace(label))
+	}
+	return m.literal("@" + command)
+}
+
+// emoji renders an emoji shortcode, or returns an empty string for names
+// that aren't emoji so they are written as text.
+func (m *mrkdwnRenderer) emoji(code string) string {
+	name := strings.Trim(code, ":")
+	if _, _, ok := m.resolver.Emoji(name); !ok {
+		return ""
+	}
+	return m.resolver.renderEmoji(name, m.markdown)
+}
+
+// literal writes text that carries no formatting of its own.
+func (m *mrkdwnRenderer) literal(text string) string {
+	if !m.markdown {
+		return text
+	}
+	return markdownEscaper.Replace(text)
+}
+
+// decodeEntity decodes one of the three HTML entities Slack escapes in text,
+// returning the decoded character and the length consumed.
+func decodeEntity(s string) (string, int) {
+	for _, entity := range []string{"&amp;", "&lt;", "&gt;"} {
+		if strings.HasPrefix(s, entity) {
+			return entityDecoder.Replace(entity), len(entity)
+		}
+	}
+	return "", 0
+}
+
+// formattingEnd returns the index of the delimiter closing a *bold*,
+// _italic_ or ~strike~ run opened at start, or -1 if there isn't one. Like
+// Slack, it requires the delimiters to hug the enclosed text and to sit at
+// word boundaries, and doesn't span lines.
+func formattingEnd(text string, start int) int {
+	delim := text[start]
+
+	if start > 0 {
+		prev, _ := utf8.DecodeLastRuneInString(text[:start])
+		if isWordRune(prev) || prev == rune(delim) {
+			return -1
+		}
+	}
+	if start+1 >= len(text) {
+		return -1
+	}
+	if next, _ := utf8.DecodeRuneInString(text[start+1:]); unicode.IsSpace(next) || next == rune(delim) {
+		return -1
+	}
+
+	for i := start + 1; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			return -1
+		case '`':
+			// Don't close inside a code span.
+			if end := strings.IndexAny(text[i+1:], "`\n"); end >= 0 && text[i+1+end] == '`' {
+				i += end + 1
+			}
+		case delim:
+			prev, _ := utf8.DecodeLastRuneInString(text[:i])
+			if unicode.IsSpace(prev) {
+				continue
+			}
+			if i+1 < len(text) {
+				if next, _ := utf8.DecodeRuneInString(text[i+1:]); isWordRune(next) {
+					continue
+				}
+			}
+			return i
+		}
+	}
+	return -1
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func escapeLinkDestination(url string) string {
+	return strings.NewReplacer(" ", "%20", "(", "%28", ")", "%29", "<", "%3C", ">", "%3E").Replace(url)
+}
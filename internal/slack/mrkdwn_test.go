@@ -0,0 +1,162 @@
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatMarkdown(t *testing.T) {
+	tests := []struct {
+		name  string
+		users map[string]string
+		input string
+		want  string
+	}{
+		{
+			name:  "plain text unchanged",
+			input: "hello world",
+			want:  "hello world",
+		},
+		{
+			name:  "single asterisk bold becomes strong",
+			input: "this is *important* stuff",
+			want:  "this is **important** stuff",
+		},
+		{
+			name:  "italic and strike",
+			input: "_maybe_ ~never~",
+			want:  "_maybe_ ~~never~~",
+		},
+		{
+			name:  "nested formatting with mention",
+			users: map[string]string{"U1": "alice"},
+			input: "*ping <@U1>*",
+			want:  "**ping @alice**",
+		},
+		{
+			name:  "intraword underscores are literal",
+			input: "snake_case_name",
+			want:  `snake\_case\_name`,
+		},
+		{
+			name:  "unmatched asterisk is escaped",
+			input: "2 * 3",
+			want:  `2 \* 3`,
+		},
+		{
+			name:  "entities are decoded and escaped",
+			input: "a &lt;b&gt; &amp; c",
+			want:  `a \<b\> \& c`,
+		},
+		{
+			name:  "inline code left untouched",
+			users: map[string]string{"U1": "alice"},
+			input: "run `echo <@U1> :smile: *x*` now",
+			want:  "run `echo <@U1> :smile: *x*` now",
+		},
+		{
+			name:  "inline code entities decoded",
+			input: "`a &amp;&amp; b`",
+			want:  "`a && b`",
+		},
+		{
+			name:  "fenced code block on its own lines",
+			input: "before ```if a &lt; b {\n  *x*\n}``` after",
+			want:  "before \n```\nif a < b {\n  *x*\n}\n```\nafter",
+		},
+		{
+			name:  "labelled link",
+			input: "see <https://example.com/a_(b)|the docs>",
+			want:  "see [the docs](https://example.com/a_%28b%29)",
+		},
+		{
+			name:  "bare link",
+			input: "see <https://example.com>",
+			want:  "see <https://example.com>",
+		},
+		{
+			name:  "blockquote",
+			input: "&gt; quoted line\nreply",
+			want:  "> quoted line\n\nreply",
+		},
+		{
+			name:  "multi-line quote",
+			input: "&gt;&gt;&gt; one\ntwo",
+			want:  "> one\\\n> two",
+		},
+		{
+			name:  "single newlines become hard breaks",
+			input: "line one\nline two\n\nnew paragraph",
+			want:  "line one\\\nline two\n\nnew paragraph",
+		},
+		{
+			name:  "leading hash is not a heading",
+			input: "#general is busy",
+			want:  `\#general is busy`,
+		},
+		{
+			name:  "emoji shortcode",
+			input: "done :white_check_mark:",
+			want:  "done ✅",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestResolver(tt.users, nil)
+			got := r.FormatMarkdown(tt.input)
+			if got != tt.want {
+				t.Errorf("FormatMarkdown(%q)\n got: %q\nwant: %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTextLeavesCodeUntouched(t *testing.T) {
+	r := newTestResolver(map[string]string{"U1": "alice"}, nil)
+
+	got := r.FormatText("<@U1> ran `say :smile: &lt;@U1&gt;` and ```<@U1>``` :smile:")
+	want := "@alice ran `say :smile: <@U1>` and ```<@U1>``` 😄"
+	if got != want {
+		t.Errorf("FormatText\n got: %q\nwant: %q", got, want)
+	}
+}
+
+func TestFormatTextSpecialSequences(t *testing.T) {
+	r := newTestResolver(nil, nil)
+	r.usergroups = map[string]string{"S1": "oncall"}
+
+	day := time.Unix(1700000000, 0).Local().Format("2006-01-02")
+
+	tests := []struct {
+		input    string
+		markdown bool
+		want     string
+	}{
+		{input: "<!here> deploy is done", want: "@here deploy is done"},
+		{input: "<!channel|channel> and <!everyone>", want: "@channel and @everyone"},
+		{input: "ping <!subteam^S1|@old-handle>", want: "ping @oncall"},
+		{input: "ping <!subteam^S2|@platform>", want: "ping @platform"},
+		{input: "ping <!subteam^S3>", want: "ping @S3"},
+		{input: "on <!date^1700000000^{date_num}|Nov 14>", want: "on " + day},
+		{input: "on <!date^bogus^{date_num}|Nov 14>", want: "on Nov 14"},
+		{input: "on <!date^1700000000^{date_num}^https://example.com|Nov 14>", want: "on " + day + " (https://example.com)"},
+		{input: "mail <mailto:bob@example.com|bob@example.com>", want: "mail bob@example.com"},
+		{input: "mail <mailto:bob@example.com|Bob>", want: "mail Bob (bob@example.com)"},
+		{input: "mail <mailto:bob@example.com|Bob_Smith>", markdown: true, want: `mail [Bob\_Smith](mailto:bob@example.com)`},
+		{input: "<!here>", markdown: true, want: "@here"},
+		{input: "<!unknown|label>", want: "label"},
+	}
+
+	for _, tt := range tests {
+		var got string
+		if tt.markdown {
+			got = r.FormatMarkdown(tt.input)
+		} else {
+			got = r.FormatText(tt.input)
+		}
+		if got != tt.want {
+			t.Errorf("format %q\n got: %q\nwant: %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package slack
+
+// defaultPageSize is the page size requested from cursor-paginated methods.
+// Slack recommends no more than 200 results per page.
+const defaultPageSize = 200
+
+// ResponseMetadata carries the cursor for the next page of a paginated response.
+type ResponseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+// PageFunc fetches a single page starting at cursor, requesting at most limit
+// items. It returns the items and the cursor for the following page, which is
+// empty when there are no more pages.
+type PageFunc[T any] func(cursor string, limit int) (items []T, nextCursor string, err error)
+
+// Pager follows Slack's response_metadata.next_cursor across pages until the
+// caller-supplied limit is reached or the results are exhausted.
+// A limit of zero or less fetches every page.
+type Pager[T any] struct {
+	fetch     PageFunc[T]
+	limit     int
+	pageSize  int
+	cursor    string
+	fetched   int
+	started   bool
+	truncated bool
+}
+
+// NewPager creates a Pager that collects up to limit items using fetch.
+func NewPager[T any](limit int, fetch PageFunc[T]) *Pager[T] {
+	return &Pager[T]{
+		fetch:    fetch,
+		limit:    limit,
+		pageSize: defaultPageSize,
+	}
+}
+
+// Next fetches the next page. It returns nil once the limit has been reached
+// or there are no more pages.
+func (p *Pager[T]) Next() ([]T, error) {
+	if !p.more() {
+		return nil, nil
+	}
+
+	size := p.pageSize
+	if p.limit > 0 && p.limit-p.fetched < size {
+		size = p.limit - p.fetched
+	}
+
+	items, next, err := p.fetch(p.cursor, size)
+	if err != nil {
+		return nil, err
+	}
+	p.started = true
+	p.cursor = next
+
+	if p.limit > 0 && p.fetched+len(items) > p.limit {
+		items = items[:p.limit-p.fetched]
+		p.truncated = true
+	}
+	p.fetched += len(items)
+
+	return items, nil
+}
+
+// All fetches pages until the limit is reached or results are exhausted.
+func (p *Pager[T]) All() ([]T, error) {
+	var all []T
+	for p.more() {
+		items, err := p.Next()
+		if err != nil {
+			return all, err
+		}
+		all = append(all, items...)
+	}
+	return all, nil
+}
+
+// HasMore reports whether Slack has further results beyond those fetched.
+func (p *Pager[T]) HasMore() bool {
+	return p.truncated || p.cursor != ""
+}
+
+// NextCursor returns the cursor for the page following the last one fetched.
+func (p *Pager[T]) NextCursor() string {
+	return p.cursor
+}
+
+func (p *Pager[T]) more() bool {
+	if p.limit > 0 && p.fetched >= p.limit {
+		return false
+	}
+	return !p.started || p.cursor != ""
+}
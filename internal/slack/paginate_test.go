@@ -0,0 +1,116 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// fakePages returns a PageFunc serving items in pages of at most perPage,
+// recording the limit requested on each call.
+func fakePages(total, perPage int, requested *[]int) PageFunc[int] {
+	return func(cursor string, limit int) ([]int, string, error) {
+		*requested = append(*requested, limit)
+
+		start := 0
+		if cursor != "" {
+			if _, err := fmt.Sscanf(cursor, "c%d", &start); err != nil {
+				return nil, "", err
+			}
+		}
+
+		size := perPage
+		if limit < size {
+			size = limit
+		}
+		end := start + size
+		if end > total {
+			end = total
+		}
+
+		items := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			items = append(items, i)
+		}
+
+		next := ""
+		if end < total {
+			next = fmt.Sprintf("c%d", end)
+		}
+		return items, next, nil
+	}
+}
+
+func TestPagerFollowsCursorsUntilLimit(t *testing.T) {
+	var requested []int
+	pager := NewPager(5, fakePages(10, 2, &requested))
+
+	items, err := pager.All()
+	if err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+	if len(items) != 5 {
+		t.Fatalf("expected 5 items, got %d", len(items))
+	}
+	for i, item := range items {
+		if item != i {
+			t.Fatalf("expected item %d at index %d, got %d", i, i, item)
+		}
+	}
+	if !pager.HasMore() {
+		t.Fatalf("expected HasMore after stopping at limit")
+	}
+	if pager.NextCursor() != "c5" {
+		t.Fatalf("expected next cursor c5, got %q", pager.NextCursor())
+	}
+	if last := requested[len(requested)-1]; last != 1 {
+		t.Fatalf("expected final page to request 1 item, got %d", last)
+	}
+}
+
+func TestPagerZeroLimitFetchesEverything(t *testing.T) {
+	var requested []int
+	pager := NewPager(0, fakePages(450, defaultPageSize, &requested))
+
+	items, err := pager.All()
+	if err != nil {
+		t.Fatalf("All returned error: %v", err)
+	}
+	if len(items) != 450 {
+		t.Fatalf("expected 450 items, got %d", len(items))
+	}
+	if pager.HasMore() {
+		t.Fatalf("expected no more results")
+	}
+	if len(requested) != 3 {
+		t.Fatalf("expected 3 page requests, got %d", len(requested))
+	}
+}
+
+func TestPagerNextReturnsNilWhenExhausted(t *testing.T) {
+	var requested []int
+	pager := NewPager(0, fakePages(3, 5, &requested))
+
+	first, err := pager.Next()
+	if err != nil || len(first) != 3 {
+		t.Fatalf("expected first page of 3, got %v (err %v)", first, err)
+	}
+	second, err := pager.Next()
+	if err != nil || second != nil {
+		t.Fatalf("expected nil page after exhaustion, got %v (err %v)", second, err)
+	}
+	if len(requested) != 1 {
+		t.Fatalf("expected a single request, got %d", len(requested))
+	}
+}
+
+func TestPagerReturnsFetchError(t *testing.T) {
+	wantErr := errors.New("boom")
+	pager := NewPager(0, func(cursor string, limit int) ([]int, string, error) {
+		return nil, "", wantErr
+	})
+
+	if _, err := pager.All(); !errors.Is(err, wantErr) {
+		t.Fatalf("expected fetch error, got %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package slack
+
+import (
+	"regexp"
+	"sync"
+)
+
+// prefetchWorkers bounds how many lookups Prefetch makes at once. The rate
+// limiter still paces them, so this only caps the connections in flight.
+const prefetchWorkers = 8
+
+var (
+	userMentionPattern    = regexp.MustCompile(`<@([UW][A-Z0-9]+)(?:\|[^>]*)?>`)
+	channelMentionPattern = regexp.MustCompile(`<#([CGD][A-Z0-9]+)\|?>`)
+)
+
+// Prefetch resolves the authors, mentions and reactors in messages using a
+// bounded pool of concurrent lookups, so that rendering the messages doesn't
+// wait on one lookup at a time. Channel mentions that carry a name aren't
+// looked up, since rendering uses the name.
+func (r *Resolver) Prefetch(messages []Message) {
+	refs := &prefetchRefs{users: map[string]bool{}, channels: map[string]bool{}}
+	for _, msg := range messages {
+		refs.message(msg)
+	}
+	r.prefetch(refs)
+}
+
+// PrefetchFiles resolves the uploaders of files and the conversations they
+// are shared in, like Prefetch.
+func (r *Resolver) PrefetchFiles(files []File) {
+	refs := &prefetchRefs{users: map[string]bool{}, channels: map[string]bool{}}
+	for _, file := range files {
+		if file.User != "" {
+			refs.users[file.User] = true
+		}
+		for _, channelID := range file.Conversations() {
+			refs.channels[channelID] = true
+		}
+	}
+	r.prefetch(refs)
+}
+
+func (r *Resolver) prefetch(refs *prefetchRefs) {
+	var lookups []func()
+	for userID := range refs.users {
+		if _, ok := r.cachedUser(userID); !ok {
+			lookups = append(lookups, func() { r.ResolveUser(userID) })
+		}
+	}
+	for channelID := range refs.channels {
+		if _, ok := r.cachedChannel(channelID); !ok {
+			lookups = append(lookups, func() { r.ResolveChannel(channelID) })
+		}
+	}
+
+	runBounded(lookups, prefetchWorkers)
+}
+
+// runBounded runs tasks with at most n running at once, returning when they
+// have all finished.
+func runBounded(tasks []func(), n int) {
+	queue := make(chan func())
+	var wg sync.WaitGroup
+	for i := 0; i < min(n, len(tasks)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for task := range queue {
+				task()
+			}
+		}()
+	}
+	for _, task := range tasks {
+		queue <- task
+	}
+	close(queue)
+	wg.Wait()
+}
+
+// prefetchRefs collects the user and channel IDs referenced by messages.
+type prefetchRefs struct {
+	users    map[string]bool
+	channels map[string]bool
+}
+
+func (p *prefetchRefs) message(msg Message) {
+	if msg.User != "" {
+		p.users[msg.User] = true
+	}
+	p.text(msg.Text)
+	p.blocks(msg.Blocks)
+	for _, att := range msg.Attachments {
+		p.text(att.Pretext)
+		p.text(att.Text)
+		p.text(att.Fallback)
+		for _, field := range att.Fields {
+			p.text(field.Value)
+		}
+		p.blocks(att.Blocks)
+	}
+	for _, reaction := range msg.Reactions {
+		for _, userID := range reaction.Users {
+			p.users[userID] = true
+		}
+	}
+}
+
+func (p *prefetchRefs) text(text string) {
+	for _, match := range userMentionPattern.FindAllStringSubmatch(text, -1) {
+		p.users[match[1]] = true
+	}
+	for _, match := range channelMentionPattern.FindAllStringSubmatch(text, -1) {
+		p.channels[match[1]] = true
+	}
+}
+
+func (p *prefetchRefs) blocks(blocks []Block) {
+	for _, block := range blocks {
+		if block.Text != nil {
+			p.text(block.Text.Text)
+		}
+		for _, field := range block.Fields {
+			p.text(field.Text)
+		}
+		p.elements(block.Elements)
+	}
+}
+
+func (p *prefetchRefs) elements(elements []BlockElement) {
+	for _, el := range elements {
+		switch el.Type {
+		case "user":
+			p.users[el.UserID] = true
+		case "channel":
+			p.channels[el.ChannelID] = true
+		case "mrkdwn":
+			p.text(el.Text.Text)
+		}
+		p.elements(el.Elements)
+	}
+}
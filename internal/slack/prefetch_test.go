@@ -0,0 +1,98 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPrefetchCollectsReferences(t *testing.T) {
+	msg := decodeMessage(t, `{
+		"user": "U1",
+		"text": "hi <@U2> and <@U3|carol> in <#C1> and <#C2|named>",
+		"blocks": [{"type": "rich_text", "elements": [{"type": "rich_text_section", "elements": [
+			{"type": "user", "user_id": "U4"},
+			{"type": "channel", "channel_id": "C3"}
+		]}]}],
+		"attachments": [{"text": "cc <@U5>", "fields": [{"title": "Owner", "value": "<@U6>"}]}],
+		"reactions": [{"name": "eyes", "count": 1, "users": ["U7"]}]
+	}`)
+
+	refs := &prefetchRefs{users: map[string]bool{}, channels: map[string]bool{}}
+	refs.message(msg)
+
+	if got, want := sortedKeys(refs.users), []string{"U1", "U2", "U3", "U4", "U5", "U6", "U7"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected users %v, got %v", want, got)
+	}
+	if got, want := sortedKeys(refs.channels), []string{"C1", "C3"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected channels %v, got %v", want, got)
+	}
+}
+
+func TestPrefetchResolvesConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	var inFlight, maxInFlight int
+	calls := map[string]int{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userID := r.URL.Query().Get("user")
+		mu.Lock()
+		calls[userID]++
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+		fmt.Fprintf(w, `{"ok":true,"user":{"id":%q,"name":"name-%s"}}`, userID, userID)
+	}))
+	defer server.Close()
+
+	var messages []Message
+	for i := 0; i < 30; i++ {
+		messages = append(messages, Message{User: fmt.Sprintf("U%02d", i), Text: "hi <@U00>"})
+	}
+
+	client := NewClient("xoxp-test")
+	client.baseURL = server.URL
+	client.sleep = func(time.Duration) {}
+	r := NewResolver(client)
+	r.Prefetch(messages)
+
+	if len(calls) != 30 {
+		t.Fatalf("expected 30 users looked up, got %d", len(calls))
+	}
+	for userID, n := range calls {
+		if n != 1 {
+			t.Fatalf("expected %s to be looked up once, got %d", userID, n)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > prefetchWorkers {
+		t.Fatalf("expected between 2 and %d concurrent lookups, got %d", prefetchWorkers, maxInFlight)
+	}
+
+	// Rendering afterwards is served from memory.
+	if got := r.ResolveUser("U05"); got != "name-U05" {
+		t.Fatalf("expected name-U05, got %q", got)
+	}
+	if len(calls) != 30 {
+		t.Fatalf("expected no further lookups, got %d", len(calls))
+	}
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
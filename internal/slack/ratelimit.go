@@ -0,0 +1,178 @@
+package slack
+
+import (
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateTier is one of Slack's Web API rate limit tiers.
+// See https://api.slack.com/apis/rate-limits
+type rateTier int
+
+const (
+	tier1 rateTier = iota + 1
+	tier2
+	tier3
+	tier4
+)
+
+// tierPerMinute is the documented minimum number of requests per minute each
+// tier allows.
+var tierPerMinute = map[rateTier]int{
+	tier1: 1,
+	tier2: 20,
+	tier3: 50,
+	tier4: 100,
+}
+
+// methodTiers maps Web API methods to their rate limit tier. Methods that are
+// not listed are treated as tier 3.
+var methodTiers = map[string]rateTier{
+	"apps.connections.open":        tier1,
+	"auth.test":                    tier4,
+	"conversations.history":        tier3,
+	"conversations.info":           tier3,
+	"conversations.list":           tier2,
+	"conversations.members":        tier4,
+	"conversations.open":           tier3,
+	"conversations.replies":        tier3,
+	"emoji.list":                   tier2,
+	"files.completeUploadExternal": tier4,
+	"files.getUploadURLExternal":   tier4,
+	"files.info":                   tier4,
+	"reactions.add":                tier3,
+	"reactions.list":               tier2,
+	"reactions.remove":             tier2,
+	"search.all":                   tier2,
+	"search.files":                 tier2,
+	"search.messages":              tier2,
+	"usergroups.list":              tier2,
+	"users.info":                   tier4,
+	"users.list":                   tier2,
+	"users.lookupByEmail":          tier3,
+}
+
+const (
+	defaultMaxRetries = 3
+	baseRetryDelay    = time.Second
+	maxRetryDelay     = 30 * time.Second
+)
+
+func tierForMethod(method string) rateTier {
+	if tier, ok := methodTiers[method]; ok {
+		return tier
+	}
+	return tier3
+}
+
+// tokenBucket paces requests for a single method. It refills at the tier's
+// per-minute rate and allows short bursts of up to a third of a minute's quota.
+type tokenBucket struct {
+	rate     float64 // tokens per second
+	burst    float64
+	tokens   float64
+	last     time.Time
+	blockTil time.Time
+}
+
+func newTokenBucket(tier rateTier, now time.Time) *tokenBucket {
+	perMinute := float64(tierPerMinute[tier])
+	burst := float64(tierPerMinute[tier] / 3)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{
+		rate:   perMinute / 60,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before
+// sending its request.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+
+	if blocked := b.blockTil.Sub(now); blocked > wait {
+		wait = blocked
+	}
+	return wait
+}
+
+// rateLimiter keeps a token bucket per Web API method so bulk commands stay
+// under Slack's limits instead of tripping them.
+type rateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	now     func() time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+func (l *rateLimiter) bucket(method string) *tokenBucket {
+	b, ok := l.buckets[method]
+	if !ok {
+		b = newTokenBucket(tierForMethod(method), l.now())
+		l.buckets[method] = b
+	}
+	return b
+}
+
+// reserve returns how long to wait before calling method.
+func (l *rateLimiter) reserve(method string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.bucket(method).reserve(l.now())
+}
+
+// block holds back further calls to method for d, used when Slack responds
+// with HTTP 429 so that concurrent callers also wait out the Retry-After.
+func (l *rateLimiter) block(method string, d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b := l.bucket(method)
+	if until := l.now().Add(d); until.After(b.blockTil) {
+		b.blockTil = until
+	}
+}
+
+// retryAfter parses the Retry-After header (in seconds), falling back to the
+// given delay when it is missing or malformed.
+func retryAfter(header http.Header, fallback time.Duration) time.Duration {
+	secs, err := strconv.Atoi(header.Get("Retry-After"))
+	if err != nil || secs < 0 {
+		return fallback
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// backoff returns an exponential delay with jitter for the given retry attempt.
+func backoff(attempt int) time.Duration {
+	d := baseRetryDelay << attempt
+	if d <= 0 || d > maxRetryDelay {
+		d = maxRetryDelay
+	}
+	jitter := time.Duration(rand.Int64N(int64(d) / 2))
+	return d/2 + jitter
+}
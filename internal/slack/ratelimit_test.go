@@ -0,0 +1,155 @@
+package slack
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client pointed at a test server that records sleeps
+// instead of performing them.
+func newTestClient(serverURL string, slept *[]time.Duration) *Client {
+	c := NewClient("xoxp-test", WithBaseURL(serverURL))
+	c.sleep = func(d time.Duration) { *slept = append(*slept, d) }
+	return c
+}
+
+func TestTokenBucketPacesAfterBurst(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := newTokenBucket(tier2, now) // 20/min: burst of 6, then one every 3s
+
+	for i := 0; i < 6; i++ {
+		if wait := b.reserve(now); wait != 0 {
+			t.Fatalf("expected burst request %d to proceed immediately, waited %v", i, wait)
+		}
+	}
+	if wait := b.reserve(now); wait != 3*time.Second {
+		t.Fatalf("expected 3s wait after burst, got %v", wait)
+	}
+
+	later := now.Add(time.Minute)
+	if wait := b.reserve(later); wait != 0 {
+		t.Fatalf("expected bucket to refill after a minute, waited %v", wait)
+	}
+}
+
+func TestRateLimiterBlockHoldsBackMethod(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := newRateLimiter()
+	l.now = func() time.Time { return now }
+
+	l.block("users.info", 10*time.Second)
+	if wait := l.reserve("users.info"); wait != 10*time.Second {
+		t.Fatalf("expected 10s wait for blocked method, got %v", wait)
+	}
+	if wait := l.reserve("users.list"); wait != 0 {
+		t.Fatalf("expected other methods to be unaffected, waited %v", wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+	if got := retryAfter(header, time.Second); got != 7*time.Second {
+		t.Fatalf("expected 7s, got %v", got)
+	}
+
+	header.Set("Retry-After", "soon")
+	if got := retryAfter(header, time.Second); got != time.Second {
+		t.Fatalf("expected fallback for malformed header, got %v", got)
+	}
+}
+
+func TestRequestRetriesRateLimitedResponses(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	if _, err := c.request("users.info", url.Values{}); err != nil {
+		t.Fatalf("request returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+	if len(slept) == 0 || slept[0] != 2*time.Second {
+		t.Fatalf("expected to honour Retry-After of 2s, slept %v", slept)
+	}
+}
+
+func TestRequestRetriesServerErrorsThenGivesUp(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	if _, err := c.request("conversations.history", url.Values{}); err == nil {
+		t.Fatalf("expected error after exhausting retries")
+	}
+	if calls != defaultMaxRetries+1 {
+		t.Fatalf("expected %d calls, got %d", defaultMaxRetries+1, calls)
+	}
+}
+
+func TestRequestDoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{"ok":false,"error":"channel_not_found"}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	if _, err := c.request("conversations.info", url.Values{}); err == nil {
+		t.Fatalf("expected API error")
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single call, got %d", calls)
+	}
+}
+
+func TestPostOnlyRetriesRateLimits(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.FormValue("channel") != "C1" {
+			t.Errorf("expected form POST with channel, got %s %v", r.Method, r.Form)
+		}
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	c := newTestClient(server.URL, &slept)
+
+	if _, err := c.post("chat.postMessage", url.Values{"channel": {"C1"}}); err == nil {
+		t.Fatalf("expected error from server failure")
+	}
+	if calls != 2 {
+		t.Fatalf("expected the 429 to be retried but not the 502, got %d calls", calls)
+	}
+}
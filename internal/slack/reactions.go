@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatReactions renders reactions as "👀 2 · ✅ 1". With names, each count is
+// followed by who reacted, e.g. "👀 2 (alice, bob)". Markdown escapes the
+// names for CommonMark.
+func (r *Resolver) FormatReactions(reactions []Reaction, names, markdown bool) string {
+	m := &mrkdwnRenderer{resolver: r, markdown: markdown}
+
+	var parts []string
+	for _, reaction := range reactions {
+		part := fmt.Sprintf("%s %d", r.renderEmoji(reaction.Name, markdown), reaction.Count)
+		if names && len(reaction.Users) > 0 {
+			users := make([]string, 0, len(reaction.Users))
+			for _, userID := range reaction.Users {
+				users = append(users, r.ResolveUser(userID))
+			}
+			list := strings.Join(users, ", ")
+			if more := reaction.Count - len(reaction.Users); more > 0 {
+				list += fmt.Sprintf(" +%d", more)
+			}
+			part += " (" + m.literal(list) + ")"
+		}
+		parts = append(parts, part)
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// FormatEmoji renders an emoji name as plain text does: Unicode for standard
+// emoji and aliases of them, or the :name: shortcode for custom and unknown
+// emoji.
+func (r *Resolver) FormatEmoji(name string) string {
+	return r.renderEmoji(name, false)
+}
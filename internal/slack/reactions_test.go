@@ -0,0 +1,21 @@
+package slack
+
+import "testing"
+
+func TestFormatReactions(t *testing.T) {
+	r := newTestResolver(map[string]string{"U1": "alice", "U2": "bob_b"}, nil)
+	reactions := []Reaction{
+		{Name: "eyes", Count: 2, Users: []string{"U1", "U2"}},
+		{Name: "+1::skin-tone-3", Count: 3, Users: []string{"U1"}},
+		{Name: "partyparrot", Count: 1, Users: []string{"U2"}},
+	}
+
+	if got, want := r.FormatReactions(reactions, false, false), "👀 2 · 👍 3 · :partyparrot: 1"; got != want {
+		t.Errorf("FormatReactions without names\n got: %q\nwant: %q", got, want)
+	}
+
+	want := `👀 2 (alice, bob\_b) · 👍 3 (alice +2) · :partyparrot: 1 (bob\_b)`
+	if got := r.FormatReactions(reactions, true, true); got != want {
+		t.Errorf("FormatReactions with names\n got: %q\nwant: %q", got, want)
+	}
+}
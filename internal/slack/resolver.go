@@ -1,18 +1,48 @@
 package slack
 
 import (
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/enescakir/emoji"
+	"github.com/lox/slack-cli/internal/cache"
 )
 
+// UserCacheTTL is how long a user's name is reused from the on-disk cache.
+const UserCacheTTL = 24 * time.Hour
+
+const (
+	userCacheKey      = "users"
+	usergroupCacheKey = "usergroups"
+)
+
+// cachedName is a name stored in the on-disk cache, with when it was looked up.
+type cachedName struct {
+	Name string    `json:"name"`
+	At   time.Time `json:"at"`
+}
+
 // Resolver resolves Slack user IDs and channel IDs to human-readable names,
 // and formats message text by replacing mentions and emoji shortcodes.
-// Results are cached for the lifetime of the Resolver.
+// Results are cached for the lifetime of the Resolver, and across runs when
+// it has an on-disk cache. It is safe for concurrent use.
 type Resolver struct {
-	client       *Client
+	client *Client
+
+	mu           sync.Mutex
 	userCache    map[string]string
 	channelCache map[string]string
+	workspaceURL *string
+	usergroups   map[string]string
+	emoji        map[string]string
+
+	cache        *cache.Cache
+	diskUsers    map[string]cachedName
+	diskLoad     bool
+	diskChannels bool
+	newUsers     map[string]cachedName
+	newChannels  map[string]channelEntry
 }
 
 // NewResolver creates a Resolver that uses the given client for API lookups.
@@ -24,6 +54,14 @@ func NewResolver(client *Client) *Resolver {
 	}
 }
 
+// NewCachedResolver creates a Resolver that also reads names from, and saves
+// them to, an on-disk cache. Call Save to write names looked up since.
+func NewCachedResolver(client *Client, c *cache.Cache) *Resolver {
+	r := NewResolver(client)
+	r.cache = c
+	return r
+}
+
 // ResolveUser returns a display name for the given user ID.
 // It prefers DisplayName, then RealName, then Username.
 // Returns "bot" for empty IDs and falls back to the raw ID on error.
@@ -31,17 +69,42 @@ func (r *Resolver) ResolveUser(userID string) string {
 	if userID == "" {
 		return "bot"
 	}
-
-	if name, ok := r.userCache[userID]; ok {
+	if name, ok := r.cachedUser(userID); ok {
 		return name
 	}
 
+	name := userID
 	user, err := r.client.GetUserInfo(userID)
-	if err != nil {
-		r.userCache[userID] = userID
-		return userID
+	if err == nil {
+		name = userName(*user)
 	}
 
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.userCache[userID] = name
+	if err == nil {
+		r.rememberUser(userID, name)
+	}
+	return name
+}
+
+// cachedUser looks a user up in memory, then in the on-disk cache.
+func (r *Resolver) cachedUser(userID string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name, ok := r.userCache[userID]; ok {
+		return name, true
+	}
+	if cached, ok := r.diskUser(userID); ok {
+		r.userCache[userID] = cached.Name
+		return cached.Name, true
+	}
+	return "", false
+}
+
+// userName prefers a user's display name, then real name, then username.
+func userName(user User) string {
 	name := user.Profile.DisplayName
 	if name == "" {
 		name = user.RealName
@@ -49,123 +112,289 @@ func (r *Resolver) ResolveUser(userID string) string {
 	if name == "" {
 		name = user.Name
 	}
-
-	r.userCache[userID] = name
 	return name
 }
 
 // ResolveChannel returns a channel name for the given channel ID.
 // Falls back to the raw ID on error.
 func (r *Resolver) ResolveChannel(channelID string) string {
-	if name, ok := r.channelCache[channelID]; ok {
+	if name, ok := r.cachedChannel(channelID); ok {
 		return name
 	}
 
+	name := channelID
 	channel, err := r.client.GetConversationInfo(channelID)
-	if err != nil {
-		r.channelCache[channelID] = channelID
-		return channelID
+	if err == nil {
+		name = channel.Name
 	}
 
-	r.channelCache[channelID] = channel.Name
-	return channel.Name
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.channelCache[channelID] = name
+	if err == nil && channel.Name != "" {
+		r.rememberChannel(*channel)
+	}
+	return name
 }
 
-// FormatText replaces user mentions (<@U123>), channel mentions (<#C123|name>),
-// URL links (<http://...|label>), and emoji shortcodes in message text.
-func (r *Resolver) FormatText(text string) string {
-	result := text
-
-	// Resolve user mentions: <@U123ABC> -> @username
-	pos := 0
-	for pos < len(result) {
-		start := strings.Index(result[pos:], "<@")
-		if start == -1 {
-			break
-		}
-		start += pos
-		end := strings.Index(result[start:], ">")
-		if end == -1 {
-			break
+// cachedChannel looks a channel up in memory, loading the on-disk channel
+// list on first use.
+func (r *Resolver) cachedChannel(channelID string) (string, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cache != nil && !r.diskChannels {
+		r.diskChannels = true
+		var entries []channelEntry
+		if r.cache.Get(channelCacheKey, ChannelCacheTTL, &entries) {
+			for _, e := range entries {
+				if _, ok := r.channelCache[e.ID]; !ok && e.Name != "" {
+					r.channelCache[e.ID] = e.Name
+				}
+			}
 		}
-		end += start
+	}
 
-		userID := result[start+2 : end]
+	name, ok := r.channelCache[channelID]
+	return name, ok
+}
 
-		// Handle <@U123|name> format — keep fallback name
-		var fallbackName string
-		if pipeIdx := strings.Index(userID, "|"); pipeIdx != -1 {
-			fallbackName = userID[pipeIdx+1:]
-			userID = userID[:pipeIdx]
-		}
+// ResolveUsergroup returns the handle of a user group, such as "oncall",
+// loading every group in the workspace on first use.
+func (r *Resolver) ResolveUsergroup(groupID string) (string, bool) {
+	r.mu.Lock()
+	groups := r.usergroups
+	r.mu.Unlock()
 
-		displayName := r.ResolveUser(userID)
-		if displayName == userID && fallbackName != "" {
-			displayName = fallbackName
-		}
-		replacement := "@" + displayName
-		result = result[:start] + replacement + result[end+1:]
-		pos = start + len(replacement)
+	if groups == nil {
+		groups = r.loadUsergroups()
+		r.mu.Lock()
+		r.usergroups = groups
+		r.mu.Unlock()
 	}
 
-	// Resolve channel mentions: <#C123ABC|channel-name> -> #channel-name
-	pos = 0
-	for pos < len(result) {
-		start := strings.Index(result[pos:], "<#")
-		if start == -1 {
-			break
-		}
-		start += pos
-		end := strings.Index(result[start:], ">")
-		if end == -1 {
-			break
+	handle, ok := groups[groupID]
+	return handle, ok && handle != ""
+}
+
+// loadUsergroups reads user group handles from the on-disk cache, or fetches
+// them. Failures leave groups unresolved rather than failing rendering.
+func (r *Resolver) loadUsergroups() map[string]string {
+	groups := make(map[string]string)
+	if r.cache != nil && r.cache.Get(usergroupCacheKey, UserCacheTTL, &groups) {
+		return groups
+	}
+
+	list, err := r.client.ListUsergroups()
+	if err != nil {
+		return groups
+	}
+	for _, group := range list {
+		groups[group.ID] = group.Handle
+	}
+	if r.cache != nil {
+		_ = r.cache.Set(usergroupCacheKey, groups)
+	}
+	return groups
+}
+
+// Permalink returns a link to the given message, using the workspace URL from
+// auth.test. Returns an empty string if the workspace URL can't be determined.
+func (r *Resolver) Permalink(channelID, ts, threadTS string) string {
+	r.mu.Lock()
+	workspaceURL := r.workspaceURL
+	r.mu.Unlock()
+
+	if workspaceURL == nil {
+		resolved := ""
+		if auth, err := r.client.AuthTest(); err == nil {
+			resolved = auth.URL
 		}
-		end += start
+		workspaceURL = &resolved
 
-		channelPart := result[start+2 : end]
+		r.mu.Lock()
+		r.workspaceURL = workspaceURL
+		r.mu.Unlock()
+	}
+
+	return Permalink(*workspaceURL, channelID, ts, threadTS)
+}
+
+// FormatText renders message text as plain text, replacing user mentions
+// (<@U123>), channel mentions (<#C123|name>), user group and broadcast
+// mentions (<!subteam^S123>, <!here>), dates (<!date^...>), URL and email
+// links (<http://...|label>), HTML entities and emoji shortcodes. Code spans
+// are left untouched.
+func (r *Resolver) FormatText(text string) string {
+	return (&mrkdwnRenderer{resolver: r}).render(text)
+}
+
+// FormatMarkdown converts Slack mrkdwn message text to CommonMark, resolving
+// mentions, links and emoji as FormatText does.
+func (r *Resolver) FormatMarkdown(text string) string {
+	return (&mrkdwnRenderer{resolver: r, markdown: true}).render(text)
+}
+
+// diskUser looks a user up in the on-disk cache, loading it on first use.
+// The caller must hold r.mu.
+func (r *Resolver) diskUser(userID string) (cachedName, bool) {
+	if r.cache == nil {
+		return cachedName{}, false
+	}
+	r.loadDiskUsers()
+
+	cached, ok := r.diskUsers[userID]
+	if !ok || time.Since(cached.At) >= UserCacheTTL {
+		return cachedName{}, false
+	}
+	return cached, true
+}
+
+// loadDiskUsers reads the on-disk user cache on first use. The caller must
+// hold r.mu.
+func (r *Resolver) loadDiskUsers() {
+	if !r.diskLoad {
+		r.diskLoad = true
+		r.cache.Get(userCacheKey, UserCacheTTL, &r.diskUsers)
+	}
+}
+
+// CachedUserIDs returns the IDs of users whose name in the on-disk cache
+// matches name, ignoring case, so a user can be found by name without
+// listing everyone in the workspace.
+func (r *Resolver) CachedUserIDs(name string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cache == nil {
+		return nil
+	}
+	r.loadDiskUsers()
 
-		var channelName string
-		if pipeIdx := strings.Index(channelPart, "|"); pipeIdx != -1 {
-			channelName = channelPart[pipeIdx+1:]
-		} else {
-			channelName = r.ResolveChannel(channelPart)
+	var ids []string
+	for id, cached := range r.diskUsers {
+		if time.Since(cached.At) < UserCacheTTL && strings.EqualFold(cached.Name, name) {
+			ids = append(ids, id)
 		}
+	}
+	sort.Strings(ids)
+	return ids
+}
 
-		replacement := "#" + channelName
-		result = result[:start] + replacement + result[end+1:]
-		pos = start + len(replacement)
+// rememberUser records a looked-up name to save later. The caller must hold
+// r.mu.
+func (r *Resolver) rememberUser(userID, name string) {
+	if r.cache == nil {
+		return
 	}
+	if r.newUsers == nil {
+		r.newUsers = make(map[string]cachedName)
+	}
+	r.newUsers[userID] = cachedName{Name: name, At: time.Now()}
+}
 
-	// Resolve URL links: <http://example.com|label> -> label (http://example.com)
-	pos = 0
-	for pos < len(result) {
-		start := strings.Index(result[pos:], "<http")
-		if start == -1 {
-			break
+// rememberChannel records a looked-up channel to save later. The caller must
+// hold r.mu.
+func (r *Resolver) rememberChannel(channel Channel) {
+	if r.cache == nil {
+		return
+	}
+	if r.newChannels == nil {
+		r.newChannels = make(map[string]channelEntry)
+	}
+	r.newChannels[channel.ID] = channelEntry{ID: channel.ID, Name: channel.Name, User: channel.User, Archived: channel.IsArchived}
+}
+
+// Save writes names looked up since the Resolver was created to the on-disk
+// cache, merging them with what is already there.
+func (r *Resolver) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cache == nil {
+		return nil
+	}
+	if len(r.newUsers) > 0 {
+		if err := saveUsers(r.cache, r.newUsers); err != nil {
+			return err
 		}
-		start += pos
-		end := strings.Index(result[start:], ">")
-		if end == -1 {
-			break
+		r.newUsers = nil
+	}
+	if len(r.newChannels) > 0 {
+		if err := saveChannels(r.cache, r.newChannels); err != nil {
+			return err
 		}
-		end += start
+		r.newChannels = nil
+	}
+	return nil
+}
 
-		linkContent := result[start+1 : end]
+// saveUsers merges names into the on-disk user cache, dropping expired ones.
+func saveUsers(c *cache.Cache, names map[string]cachedName) error {
+	var users map[string]cachedName
+	c.Get(userCacheKey, UserCacheTTL, &users)
+	if users == nil {
+		users = make(map[string]cachedName, len(names))
+	}
+	for id, cached := range users {
+		if time.Since(cached.At) >= UserCacheTTL {
+			delete(users, id)
+		}
+	}
+	for id, cached := range names {
+		users[id] = cached
+	}
+	return c.Set(userCacheKey, users)
+}
 
-		var replacement string
-		if pipeIdx := strings.Index(linkContent, "|"); pipeIdx != -1 {
-			linkURL := linkContent[:pipeIdx]
-			label := linkContent[pipeIdx+1:]
-			replacement = label + " (" + linkURL + ")"
-		} else {
-			replacement = linkContent
+// saveChannels merges channels into the on-disk channel list. The list may
+// then be partial, which is fine: ChannelResolver refetches it when a name
+// isn't in it.
+func saveChannels(c *cache.Cache, channels map[string]channelEntry) error {
+	var entries []channelEntry
+	c.Get(channelCacheKey, ChannelCacheTTL, &entries)
+	listed := make(map[string]bool, len(entries))
+	for i, e := range entries {
+		listed[e.ID] = true
+		if updated, ok := channels[e.ID]; ok {
+			entries[i] = updated
+		}
+	}
+	var ids []string
+	for id := range channels {
+		if !listed[id] {
+			ids = append(ids, id)
 		}
-		result = result[:start] + replacement + result[end+1:]
-		pos = start + len(replacement)
 	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		entries = append(entries, channels[id])
+	}
+	return c.Set(channelCacheKey, entries)
+}
 
-	// Convert emoji shortcodes: :smile: -> 😊
-	result = emoji.Parse(result)
+// WarmCache fetches every user and conversation in the workspace into the
+// on-disk cache, returning how many of each it stored.
+func WarmCache(client *Client, c *cache.Cache) (users, channels int, err error) {
+	members, err := client.UsersPager(0).All()
+	if err != nil {
+		return 0, 0, err
+	}
+	now := time.Now()
+	names := make(map[string]cachedName, len(members))
+	for _, user := range members {
+		names[user.ID] = cachedName{Name: userName(user), At: now}
+	}
+	if err := saveUsers(c, names); err != nil {
+		return 0, 0, err
+	}
 
-	return result
+	resolver := NewChannelResolver(client, c)
+	if err := resolver.fetch(); err != nil {
+		return len(names), 0, err
+	}
+	if err := c.Set(channelCacheKey, resolver.entries); err != nil {
+		return len(names), 0, err
+	}
+	return len(names), len(resolver.entries), nil
 }
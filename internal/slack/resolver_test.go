@@ -1,6 +1,13 @@
 package slack
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/lox/slack-cli/internal/cache"
+)
 
 // newTestResolver creates a Resolver with pre-populated caches (no API calls needed).
 func newTestResolver(users map[string]string, channels map[string]string) *Resolver {
@@ -13,6 +20,8 @@ func newTestResolver(users map[string]string, channels map[string]string) *Resol
 	return &Resolver{
 		userCache:    users,
 		channelCache: channels,
+		usergroups:   map[string]string{},
+		emoji:        map[string]string{},
 	}
 }
 
@@ -99,3 +108,100 @@ func TestFormatText(t *testing.T) {
 		})
 	}
 }
+
+func TestCachedResolverReusesNamesAcrossResolvers(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users.info" {
+			t.Errorf("expected users.info, got %s", r.URL.Path)
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U1","name":"alice","profile":{"display_name":"Alice"}}}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	client := newTestClient(server.URL, &slept)
+	c := cache.New(t.TempDir())
+
+	first := NewCachedResolver(client, c)
+	if got := first.ResolveUser("U1"); got != "Alice" {
+		t.Fatalf("expected Alice, got %q", got)
+	}
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if got := NewCachedResolver(client, c).ResolveUser("U1"); got != "Alice" {
+		t.Fatalf("expected Alice from the cache, got %q", got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one users.info call, got %d", calls)
+	}
+}
+
+func TestCachedResolverReusesChannelNamesAcrossResolvers(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/conversations.info" {
+			t.Errorf("expected conversations.info, got %s", r.URL.Path)
+		}
+		calls++
+		_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"C1","name":"general"}}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	client := newTestClient(server.URL, &slept)
+	c := cache.New(t.TempDir())
+
+	first := NewCachedResolver(client, c)
+	if got := first.ResolveChannel("C1"); got != "general" {
+		t.Fatalf("expected general, got %q", got)
+	}
+	if err := first.Save(); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	if got := NewCachedResolver(client, c).ResolveChannel("C1"); got != "general" {
+		t.Fatalf("expected general from the cache, got %q", got)
+	}
+	if calls != 1 {
+		t.Fatalf("expected one conversations.info call, got %d", calls)
+	}
+}
+
+func TestWarmCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users.list":
+			_, _ = w.Write([]byte(`{"ok":true,"members":[{"id":"U1","name":"alice"},{"id":"U2","name":"bob","real_name":"Bob"}]}`))
+		case "/conversations.list":
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C1","name":"general"}]}`))
+		default:
+			t.Errorf("unexpected call to %s", r.URL.Path)
+			_, _ = w.Write([]byte(`{"ok":false,"error":"unknown_method"}`))
+		}
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	client := newTestClient(server.URL, &slept)
+	c := cache.New(t.TempDir())
+
+	users, channels, err := WarmCache(client, c)
+	if err != nil {
+		t.Fatalf("WarmCache returned error: %v", err)
+	}
+	if users != 2 || channels != 1 {
+		t.Fatalf("expected 2 users and 1 channel, got %d and %d", users, channels)
+	}
+
+	resolver := NewCachedResolver(client, c)
+	if got := resolver.ResolveUser("U2"); got != "Bob" {
+		t.Fatalf("expected Bob, got %q", got)
+	}
+	if got := resolver.ResolveChannel("C1"); got != "general" {
+		t.Fatalf("expected general, got %q", got)
+	}
+}
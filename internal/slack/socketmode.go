@@ -0,0 +1,275 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// socketOrigin is the Origin header sent when dialling a Socket Mode URL.
+const socketOrigin = "https://slack.com"
+
+// ErrSocketModeDisabled is returned when Slack closes a Socket Mode connection
+// because Socket Mode was turned off for the app.
+var ErrSocketModeDisabled = errors.New("socket mode is disabled for this app")
+
+// OpenSocketConnection calls apps.connections.open for a Socket Mode
+// WebSocket URL. The client must use an app-level token (xapp-...) with the
+// connections:write scope.
+func (c *Client) OpenSocketConnection() (string, error) {
+	body, err := c.post("apps.connections.open", url.Values{})
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to parse connection response: %w", err)
+	}
+	if result.URL == "" {
+		return "", fmt.Errorf("no WebSocket URL in apps.connections.open response")
+	}
+
+	return result.URL, nil
+}
+
+// Event is an Events API event, such as a message, reaction or change to a
+// channel, with the fields common to those events pulled out.
+type Event struct {
+	Type    string
+	Subtype string
+	// Channel is the ID of the conversation the event happened in. For
+	// reactions it is the conversation of the message reacted to.
+	Channel string
+	// ChannelName is set by channel events that carry the channel's name,
+	// such as channel_created and channel_rename.
+	ChannelName string
+	// User is who caused the event. For edited messages it is the message's
+	// author, and for created channels their creator.
+	User     string
+	Text     string
+	TS       string
+	ThreadTS string
+	// Reaction and ItemTS are the emoji and the message of a reaction_added
+	// or reaction_removed event.
+	Reaction string
+	ItemTS   string
+	// EventTS is when the event happened.
+	EventTS string
+	// Raw is the event as Slack sent it.
+	Raw json.RawMessage
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var wire struct {
+		Type     string          `json:"type"`
+		Subtype  string          `json:"subtype"`
+		Channel  json.RawMessage `json:"channel"`
+		User     json.RawMessage `json:"user"`
+		Text     string          `json:"text"`
+		TS       string          `json:"ts"`
+		ThreadTS string          `json:"thread_ts"`
+		EventTS  string          `json:"event_ts"`
+		Reaction string          `json:"reaction"`
+		Item     struct {
+			Channel string `json:"channel"`
+			TS      string `json:"ts"`
+		} `json:"item"`
+		// Message is the new version of an edited message.
+		Message *struct {
+			User     string `json:"user"`
+			Text     string `json:"text"`
+			TS       string `json:"ts"`
+			ThreadTS string `json:"thread_ts"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+
+	*e = Event{
+		Type:     wire.Type,
+		Subtype:  wire.Subtype,
+		Text:     wire.Text,
+		TS:       wire.TS,
+		ThreadTS: wire.ThreadTS,
+		EventTS:  wire.EventTS,
+		Reaction: wire.Reaction,
+		ItemTS:   wire.Item.TS,
+		Raw:      append(json.RawMessage(nil), data...),
+	}
+
+	// Channel events carry the whole channel rather than its ID.
+	var channel struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		Creator string `json:"creator"`
+	}
+	if json.Unmarshal(wire.Channel, &e.Channel) != nil && json.Unmarshal(wire.Channel, &channel) == nil {
+		e.Channel, e.ChannelName, e.User = channel.ID, channel.Name, channel.Creator
+	}
+	if e.Channel == "" {
+		e.Channel = wire.Item.Channel
+	}
+
+	// user is an ID in most events but the whole user in some, e.g. user_change.
+	var user struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(wire.User, &e.User) != nil && json.Unmarshal(wire.User, &user) == nil {
+		e.User = user.ID
+	}
+
+	if wire.Message != nil {
+		e.User, e.Text, e.TS, e.ThreadTS = wire.Message.User, wire.Message.Text, wire.Message.TS, wire.Message.ThreadTS
+	}
+	return nil
+}
+
+// socketEnvelope is a message Slack sends over a Socket Mode connection.
+type socketEnvelope struct {
+	EnvelopeID string `json:"envelope_id"`
+	// Type is "hello" once connected, "events_api" for events and
+	// "disconnect" when Slack is about to close the connection.
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Payload struct {
+		Event json.RawMessage `json:"event"`
+	} `json:"payload"`
+}
+
+// SocketMode receives Events API events over Socket Mode. It acknowledges
+// each envelope as it arrives and reconnects whenever the connection drops or
+// Slack asks for a new one.
+type SocketMode struct {
+	client *Client
+	// URL, if set, is dialled instead of a URL from apps.connections.open,
+	// such as a local stand-in for Slack.
+	URL string
+	// Logf, if set, is told about dropped connections and skipped events.
+	Logf func(format string, args ...any)
+	// backoff returns how long to wait before a reconnect attempt.
+	backoff func(attempt int) time.Duration
+}
+
+// NewSocketMode returns a SocketMode that opens connections with client,
+// which must use an app-level token.
+func NewSocketMode(client *Client) *SocketMode {
+	return &SocketMode{client: client, backoff: backoff}
+}
+
+// Run calls handle with each event received until ctx is cancelled or the
+// connection fails permanently, such as when the app-level token is rejected.
+// Events are handled one at a time, in the order they arrive.
+func (s *SocketMode) Run(ctx context.Context, handle func(Event)) error {
+	for attempt := 0; ; attempt++ {
+		connected, err := s.session(ctx, handle)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if isPermanentSocketError(err) {
+			return err
+		}
+		if connected {
+			attempt = 0
+		}
+		if err != nil {
+			s.logf("%v; reconnecting", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.backoff(attempt)):
+		}
+	}
+}
+
+// session handles one connection until it closes, reporting whether Slack
+// said hello. A nil error means Slack asked to reconnect.
+func (s *SocketMode) session(ctx context.Context, handle func(Event)) (connected bool, err error) {
+	wsURL := s.URL
+	if wsURL == "" {
+		if wsURL, err = s.client.OpenSocketConnection(); err != nil {
+			return false, fmt.Errorf("failed to open socket mode connection: %w", err)
+		}
+	}
+
+	config, err := websocket.NewConfig(wsURL, socketOrigin)
+	if err != nil {
+		return false, fmt.Errorf("invalid socket mode URL: %w", err)
+	}
+	if s.client != nil {
+		// Connect the way API calls do, e.g. trusting the same CAs.
+		config.Header.Set("User-Agent", s.client.userAgent)
+		if transport, ok := s.client.httpClient.Transport.(*http.Transport); ok {
+			config.TlsConfig = transport.TLSClientConfig
+		}
+	}
+	conn, err := config.DialContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close() //nolint:errcheck
+
+	// Closing the connection interrupts the blocking read below.
+	stop := context.AfterFunc(ctx, func() { _ = conn.Close() })
+	defer stop()
+
+	for {
+		var envelope socketEnvelope
+		if err := websocket.JSON.Receive(conn, &envelope); err != nil {
+			return connected, fmt.Errorf("socket mode connection lost: %w", err)
+		}
+
+		// Slack redelivers envelopes that aren't acknowledged promptly, so
+		// acknowledge before handling.
+		if envelope.EnvelopeID != "" {
+			ack := map[string]string{"envelope_id": envelope.EnvelopeID}
+			if err := websocket.JSON.Send(conn, ack); err != nil {
+				return connected, fmt.Errorf("failed to acknowledge event: %w", err)
+			}
+		}
+
+		switch envelope.Type {
+		case "hello":
+			connected = true
+		case "disconnect":
+			if envelope.Reason == "link_disabled" {
+				return connected, ErrSocketModeDisabled
+			}
+			return connected, nil
+		case "events_api":
+			var event Event
+			if err := json.Unmarshal(envelope.Payload.Event, &event); err != nil {
+				s.logf("skipping event Slack sent that couldn't be parsed: %v", err)
+				continue
+			}
+			handle(event)
+		}
+	}
+}
+
+func (s *SocketMode) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
+
+// isPermanentSocketError reports whether reconnecting after err is pointless,
+// because Socket Mode is off or Slack rejected the request outright.
+func isPermanentSocketError(err error) bool {
+	if errors.Is(err, ErrSocketModeDisabled) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code != "" && !IsRateLimited(err)
+}
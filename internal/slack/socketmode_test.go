@@ -0,0 +1,176 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+// socketStandIn serves Socket Mode connections, sending each connection its
+// script of envelopes and recording the acknowledgements it gets back.
+type socketStandIn struct {
+	mu          sync.Mutex
+	connections int
+	acks        []string
+	// scripted is done once every script has been sent.
+	scripted sync.WaitGroup
+}
+
+func (s *socketStandIn) serve(t *testing.T, scripts ...[]string) *httptest.Server {
+	t.Helper()
+	s.scripted.Add(len(scripts))
+	return httptest.NewServer(websocket.Handler(func(conn *websocket.Conn) {
+		s.mu.Lock()
+		n := s.connections
+		s.connections++
+		s.mu.Unlock()
+		if n >= len(scripts) {
+			// Hold the last connection open until the client hangs up.
+			var discard any
+			_ = websocket.JSON.Receive(conn, &discard)
+			return
+		}
+		defer s.scripted.Done()
+
+		for _, envelope := range scripts[n] {
+			if err := websocket.Message.Send(conn, envelope); err != nil {
+				t.Errorf("failed to send envelope: %v", err)
+				return
+			}
+			if !strings.Contains(envelope, "envelope_id") {
+				continue
+			}
+			var ack struct {
+				EnvelopeID string `json:"envelope_id"`
+			}
+			if err := websocket.JSON.Receive(conn, &ack); err != nil {
+				t.Errorf("expected an acknowledgement: %v", err)
+				return
+			}
+			s.mu.Lock()
+			s.acks = append(s.acks, ack.EnvelopeID)
+			s.mu.Unlock()
+		}
+	}))
+}
+
+func TestSocketModeAcknowledgesAndReconnects(t *testing.T) {
+	standIn := &socketStandIn{}
+	server := standIn.serve(t,
+		[]string{
+			`{"type":"hello"}`,
+			`{"envelope_id":"e1","type":"events_api","payload":{"event":{"type":"message","channel":"C1","user":"U1","text":"hi","ts":"1700000000.000100"}}}`,
+			`{"type":"disconnect","reason":"refresh_requested"}`,
+		},
+		[]string{
+			`{"type":"hello"}`,
+			`{"envelope_id":"e2","type":"events_api","payload":{"event":{"type":"reaction_added","user":"U2","reaction":"tada","item":{"type":"message","channel":"C1","ts":"1700000000.000100"}}}}`,
+		},
+	)
+	defer server.Close()
+
+	s := NewSocketMode(nil)
+	s.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+	s.backoff = func(int) time.Duration { return 0 }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var events []Event
+	err := s.Run(ctx, func(event Event) {
+		events = append(events, event)
+		if len(events) == 2 {
+			cancel()
+		}
+	})
+	if err != nil {
+		t.Fatalf("Run returned error: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %+v", events)
+	}
+	if events[0].Type != "message" || events[0].Channel != "C1" || events[0].Text != "hi" {
+		t.Fatalf("unexpected message event %+v", events[0])
+	}
+	if events[1].Reaction != "tada" || events[1].Channel != "C1" || events[1].ItemTS != "1700000000.000100" || events[1].User != "U2" {
+		t.Fatalf("unexpected reaction event %+v", events[1])
+	}
+
+	standIn.scripted.Wait()
+	standIn.mu.Lock()
+	defer standIn.mu.Unlock()
+	if standIn.connections != 2 {
+		t.Fatalf("expected a reconnect after the disconnect, got %d connections", standIn.connections)
+	}
+	if strings.Join(standIn.acks, ",") != "e1,e2" {
+		t.Fatalf("expected both envelopes acknowledged, got %v", standIn.acks)
+	}
+}
+
+func TestSocketModeStopsWhenDisabled(t *testing.T) {
+	standIn := &socketStandIn{}
+	server := standIn.serve(t, []string{
+		`{"type":"hello"}`,
+		`{"type":"disconnect","reason":"link_disabled"}`,
+	})
+	defer server.Close()
+
+	s := NewSocketMode(nil)
+	s.URL = "ws" + strings.TrimPrefix(server.URL, "http")
+	s.backoff = func(int) time.Duration { return 0 }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.Run(ctx, func(Event) {}); err != ErrSocketModeDisabled {
+		t.Fatalf("expected ErrSocketModeDisabled, got %v", err)
+	}
+}
+
+func TestSocketModeStopsOnInvalidAppToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/apps.connections.open" {
+			t.Errorf("expected apps.connections.open, got %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	}))
+	defer server.Close()
+
+	var slept []time.Duration
+	s := NewSocketMode(newTestClient(server.URL, &slept))
+	s.backoff = func(int) time.Duration { return 0 }
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.Run(ctx, func(Event) {}); !IsTokenRevoked(err) {
+		t.Fatalf("expected an invalid_auth error, got %v", err)
+	}
+}
+
+func TestEventUnmarshalChannelEvents(t *testing.T) {
+	var created Event
+	if err := created.UnmarshalJSON([]byte(`{"type":"channel_created","channel":{"id":"C9","name":"launch","creator":"U1"}}`)); err != nil {
+		t.Fatalf("UnmarshalJSON returned error: %v", err)
+	}
+	if created.Channel != "C9" || created.ChannelName != "launch" || created.User != "U1" {
+		t.Fatalf("unexpected channel_created event %+v", created)
+	}
+
+	var edited Event
+	err := edited.UnmarshalJSON([]byte(`{"type":"message","subtype":"message_changed","channel":"C1","ts":"1700000001.000000",
+		"message":{"user":"U2","text":"fixed","ts":"1700000000.000100"}}`))
+	if err != nil {
+		t.Fatalf("UnmarshalJSON returned error: %v", err)
+	}
+	if edited.Channel != "C1" || edited.User != "U2" || edited.Text != "fixed" || edited.TS != "1700000000.000100" {
+		t.Fatalf("unexpected message_changed event %+v", edited)
+	}
+}
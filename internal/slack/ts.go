@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FlexibleTS is a timestamp that Slack, or an integration posting through
+// it, may send as either a JSON number or a string. Strings that aren't
+// timestamps are kept as they are, and anything else is treated as missing,
+// so one odd value doesn't spoil the response it's part of.
+type FlexibleTS string
+
+func (t *FlexibleTS) UnmarshalJSON(data []byte) error {
+	var s string
+	switch {
+	case json.Unmarshal(data, &s) == nil:
+		*t = FlexibleTS(s)
+	case len(data) > 0 && (data[0] == '-' || ('0' <= data[0] && data[0] <= '9')):
+		*t = FlexibleTS(bytes.TrimSpace(data))
+	default:
+		*t = ""
+	}
+	return nil
+}
+
+func (t FlexibleTS) String() string {
+	return string(t)
+}
+
+// ParseTS converts a Slack message timestamp such as "1700000000.123456" to a
+// time.Time.
+func ParseTS(ts string) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid Slack timestamp %q", ts)
+	}
+
+	var usec int64
+	if fracPart != "" {
+		if len(fracPart) > 6 {
+			fracPart = fracPart[:6]
+		}
+		usec, err = strconv.ParseInt(fracPart+strings.Repeat("0", 6-len(fracPart)), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid Slack timestamp %q", ts)
+		}
+	}
+
+	return time.Unix(sec, usec*1000), nil
+}
+
+// FormatTS converts a time.Time to a Slack timestamp with microsecond precision.
+func FormatTS(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+// Permalink builds a message permalink from a workspace URL such as
+// "https://acme.slack.com/". threadTS is only used for replies, where it
+// differs from ts.
+func Permalink(workspaceURL, channelID, ts, threadTS string) string {
+	if workspaceURL == "" || channelID == "" || ts == "" {
+		return ""
+	}
+
+	link := strings.TrimSuffix(workspaceURL, "/") + "/archives/" + channelID + "/p" + strings.ReplaceAll(ts, ".", "")
+	if threadTS != "" && threadTS != ts {
+		link += "?thread_ts=" + threadTS + "&cid=" + channelID
+	}
+	return link
+}
@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTS(t *testing.T) {
+	got, err := ParseTS("1700000000.123456")
+	if err != nil {
+		t.Fatalf("ParseTS returned error: %v", err)
+	}
+	want := time.Unix(1700000000, 123456000)
+	if !got.Equal(want) {
+		t.Fatalf("ParseTS = %v, want %v", got, want)
+	}
+
+	if _, err := ParseTS("not-a-ts"); err == nil {
+		t.Fatalf("expected error for invalid timestamp")
+	}
+}
+
+func TestFormatTSRoundTrip(t *testing.T) {
+	ts := "1700000000.000100"
+	parsed, err := ParseTS(ts)
+	if err != nil {
+		t.Fatalf("ParseTS returned error: %v", err)
+	}
+	if got := FormatTS(parsed); got != ts {
+		t.Fatalf("FormatTS = %q, want %q", got, ts)
+	}
+}
+
+func TestPermalink(t *testing.T) {
+	tests := []struct {
+		name     string
+		ts       string
+		threadTS string
+		want     string
+	}{
+		{
+			name: "top-level message",
+			ts:   "1700000000.123456",
+			want: "https://acme.slack.com/archives/C123/p1700000000123456",
+		},
+		{
+			name:     "thread parent",
+			ts:       "1700000000.123456",
+			threadTS: "1700000000.123456",
+			want:     "https://acme.slack.com/archives/C123/p1700000000123456",
+		},
+		{
+			name:     "reply",
+			ts:       "1700000100.000001",
+			threadTS: "1700000000.123456",
+			want:     "https://acme.slack.com/archives/C123/p1700000100000001?thread_ts=1700000000.123456&cid=C123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Permalink("https://acme.slack.com/", "C123", tt.ts, tt.threadTS); got != tt.want {
+				t.Fatalf("Permalink = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := Permalink("", "C123", "1700000000.123456", ""); got != "" {
+		t.Fatalf("expected empty permalink without workspace URL, got %q", got)
+	}
+}
@@ -1,15 +1,61 @@
 package slack
 
 type Message struct {
-	Type       string   `json:"type"`
-	User       string   `json:"user"`
-	Text       string   `json:"text"`
-	TS         string   `json:"ts"`
-	ThreadTS   string   `json:"thread_ts,omitempty"`
-	ReplyCount int      `json:"reply_count,omitempty"`
-	Channel    *Channel `json:"channel,omitempty"`
-	Permalink  string   `json:"permalink,omitempty"`
-	Files      []File   `json:"files,omitempty"`
+	Type        string       `json:"type"`
+	User        string       `json:"user"`
+	Text        string       `json:"text"`
+	TS          string       `json:"ts"`
+	ThreadTS    string       `json:"thread_ts,omitempty"`
+	ReplyCount  int          `json:"reply_count,omitempty"`
+	Channel     *Channel     `json:"channel,omitempty"`
+	Permalink   string       `json:"permalink,omitempty"`
+	Files       []File       `json:"files,omitempty"`
+	Blocks      []Block      `json:"blocks,omitempty"`
+	Attachments []Attachment `json:"attachments,omitempty"`
+	Reactions   []Reaction   `json:"reactions,omitempty"`
+	Edited      *Edited      `json:"edited,omitempty"`
+}
+
+// Edited records who last edited a message, and when.
+type Edited struct {
+	User string `json:"user"`
+	TS   string `json:"ts"`
+}
+
+// Reaction is an emoji reaction on a message. Users may list fewer users than
+// Count for popular reactions.
+type Reaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users,omitempty"`
+}
+
+// Attachment is a legacy message attachment, as sent by most alerting
+// integrations, or a link unfurl preview.
+type Attachment struct {
+	Color       string            `json:"color,omitempty"`
+	Fallback    string            `json:"fallback,omitempty"`
+	Pretext     string            `json:"pretext,omitempty"`
+	AuthorName  string            `json:"author_name,omitempty"`
+	AuthorLink  string            `json:"author_link,omitempty"`
+	Title       string            `json:"title,omitempty"`
+	TitleLink   string            `json:"title_link,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Fields      []AttachmentField `json:"fields,omitempty"`
+	ImageURL    string            `json:"image_url,omitempty"`
+	ThumbURL    string            `json:"thumb_url,omitempty"`
+	Footer      string            `json:"footer,omitempty"`
+	TS          FlexibleTS        `json:"ts,omitempty"`
+	ServiceName string            `json:"service_name,omitempty"`
+	FromURL     string            `json:"from_url,omitempty"`
+	OriginalURL string            `json:"original_url,omitempty"`
+	Blocks      []Block           `json:"blocks,omitempty"`
+}
+
+type AttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
 }
 
 type File struct {
@@ -21,18 +67,29 @@ type File struct {
 	Size               int    `json:"size"`
 	URLPrivate         string `json:"url_private"`
 	URLPrivateDownload string `json:"url_private_download"`
+	Permalink          string `json:"permalink,omitempty"`
+	PrettyType         string `json:"pretty_type,omitempty"`
+	// User uploaded the file, at Created (Unix seconds).
+	User    string `json:"user,omitempty"`
+	Created int64  `json:"created,omitempty"`
+	// Channels, Groups and IMs are the conversations the file is shared in.
+	Channels []string `json:"channels,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	IMs      []string `json:"ims,omitempty"`
 }
 
 type RepliesResponse struct {
-	OK       bool      `json:"ok"`
-	Messages []Message `json:"messages"`
-	HasMore  bool      `json:"has_more"`
+	OK               bool             `json:"ok"`
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type HistoryResponse struct {
-	OK       bool      `json:"ok"`
-	Messages []Message `json:"messages"`
-	HasMore  bool      `json:"has_more"`
+	OK               bool             `json:"ok"`
+	Messages         []Message        `json:"messages"`
+	HasMore          bool             `json:"has_more"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type User struct {
@@ -45,6 +102,12 @@ type User struct {
 	Profile  Profile `json:"profile"`
 }
 
+type Usergroup struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Handle string `json:"handle"`
+}
+
 type Profile struct {
 	DisplayName string `json:"display_name"`
 	Email       string `json:"email"`
@@ -52,8 +115,10 @@ type Profile struct {
 }
 
 type UsersResponse struct {
-	OK      bool   `json:"ok"`
-	Members []User `json:"members"`
+	OK               bool             `json:"ok"`
+	Members          []User           `json:"members"`
+	HasMore          bool             `json:"-"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type Channel struct {
@@ -66,6 +131,7 @@ type Channel struct {
 	IsPrivate  bool   `json:"is_private"`
 	IsArchived bool   `json:"is_archived"`
 	NumMembers int    `json:"num_members"`
+	User       string `json:"user,omitempty"`
 	Topic      Topic  `json:"topic,omitempty"`
 	Purpose    Topic  `json:"purpose,omitempty"`
 }
@@ -75,8 +141,10 @@ type Topic struct {
 }
 
 type ConversationsResponse struct {
-	OK       bool      `json:"ok"`
-	Channels []Channel `json:"channels"`
+	OK               bool             `json:"ok"`
+	Channels         []Channel        `json:"channels"`
+	HasMore          bool             `json:"-"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
 }
 
 type SearchResponse struct {
@@ -84,7 +152,20 @@ type SearchResponse struct {
 	Messages struct {
 		Total   int           `json:"total"`
 		Matches []SearchMatch `json:"matches"`
+		Paging  SearchPaging  `json:"paging"`
 	} `json:"messages"`
+	Files struct {
+		Total   int          `json:"total"`
+		Matches []File       `json:"matches"`
+		Paging  SearchPaging `json:"paging"`
+	} `json:"files"`
+}
+
+type SearchPaging struct {
+	Count int `json:"count"`
+	Total int `json:"total"`
+	Page  int `json:"page"`
+	Pages int `json:"pages"`
 }
 
 type SearchMatch struct {
@@ -102,6 +183,28 @@ type SearchChannel struct {
 	Name string `json:"name"`
 }
 
+// ReactionItem is an item the user has reacted to, as returned by
+// reactions.list. Only message items are used.
+type ReactionItem struct {
+	Type    string  `json:"type"`
+	Channel string  `json:"channel"`
+	Message Message `json:"message"`
+}
+
+type ReactionsResponse struct {
+	OK               bool             `json:"ok"`
+	Items            []ReactionItem   `json:"items"`
+	HasMore          bool             `json:"-"`
+	ResponseMetadata ResponseMetadata `json:"response_metadata"`
+}
+
+type PostMessageResponse struct {
+	OK      bool    `json:"ok"`
+	Channel string  `json:"channel"`
+	TS      string  `json:"ts"`
+	Message Message `json:"message"`
+}
+
 type AuthTestResponse struct {
 	OK     bool   `json:"ok"`
 	URL    string `json:"url"`
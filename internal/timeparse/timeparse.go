@@ -0,0 +1,205 @@
+// Package timeparse parses the absolute and human-friendly times accepted by
+// flags such as --since and --until.
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	slackTSPattern   = regexp.MustCompile(`^\d{9,10}(\.\d+)?$`)
+	durationPattern  = regexp.MustCompile(`^(\d+)\s*(s|sec|secs|m|min|mins|h|hr|hrs|d|day|days|w|wk|wks|week|weeks)(\s+ago)?$`)
+	clockPattern     = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+	absoluteLayouts  = []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+	weekdaysByName   = map[string]time.Weekday{}
+	durationUnitSize = map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+)
+
+func init() {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		weekdaysByName[name] = d
+		weekdaysByName[name[:3]] = d
+	}
+}
+
+// Parse converts s to a time, interpreting relative expressions against now
+// and dates without a zone in now's location. It accepts:
+//
+//   - RFC 3339 ("2024-05-01T09:00:00Z") and local dates/times ("2024-05-01", "2024-05-01 09:00")
+//   - Slack timestamps ("1714554000.123456") and Unix seconds
+//   - durations before now ("90m", "2d", "1w", "3 days ago")
+//   - "now", "today", "yesterday", weekdays ("monday", "last monday")
+//   - any of the day expressions combined with a time of day ("yesterday 9am", "noon yesterday", "monday 14:30")
+func Parse(s string, now time.Time) (time.Time, error) {
+	input := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if input == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	if input == "now" {
+		return now, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(input)); err == nil {
+		return t, nil
+	}
+
+	for _, layout := range absoluteLayouts {
+		if t, err := time.ParseInLocation(layout, input, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	if slackTSPattern.MatchString(input) {
+		return parseUnix(input)
+	}
+
+	if m := durationPattern.FindStringSubmatch(input); m != nil {
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid duration %q", s)
+		}
+		return now.Add(-time.Duration(n) * durationUnitSize[m[2][0]]), nil
+	}
+
+	if t, ok := parseDayAndClock(input, now); ok {
+		return t, nil
+	}
+
+	return time.Time{}, fmt.Errorf("unrecognised time %q (try RFC3339, 2024-05-01, 2d, yesterday or 'last monday 9am')", s)
+}
+
+func parseUnix(s string) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(s, ".")
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	var nsec int64
+	if fracPart != "" {
+		if len(fracPart) > 9 {
+			fracPart = fracPart[:9]
+		}
+		nsec, err = strconv.ParseInt(fracPart+strings.Repeat("0", 9-len(fracPart)), 10, 64)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// parseDayAndClock handles a day expression, a time of day, or both in
+// either order.
+func parseDayAndClock(input string, now time.Time) (time.Time, bool) {
+	fields := strings.Fields(input)
+
+	// A time of day may come first ("9am yesterday") or last ("yesterday 9am").
+	if hour, minute, ok := parseClock(fields[len(fields)-1]); ok {
+		day, ok := parseDay(strings.Join(fields[:len(fields)-1], " "), now)
+		if !ok {
+			return time.Time{}, false
+		}
+		return atClock(day, hour, minute), true
+	}
+	if hour, minute, ok := parseClock(fields[0]); ok {
+		day, ok := parseDay(strings.Join(fields[1:], " "), now)
+		if !ok {
+			return time.Time{}, false
+		}
+		return atClock(day, hour, minute), true
+	}
+
+	return parseDay(input, now)
+}
+
+// parseDay returns the start of the day described by expr. An empty
+// expression means today.
+func parseDay(expr string, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch expr {
+	case "", "today":
+		return today, true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "last week":
+		return today.AddDate(0, 0, -7), true
+	}
+
+	strictlyBefore := false
+	if name, ok := strings.CutPrefix(expr, "last "); ok {
+		expr = name
+		strictlyBefore = true
+	}
+
+	weekday, ok := weekdaysByName[expr]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	daysBack := (int(today.Weekday()) - int(weekday) + 7) % 7
+	if daysBack == 0 && strictlyBefore {
+		daysBack = 7
+	}
+	return today.AddDate(0, 0, -daysBack), true
+}
+
+// parseClock parses a time of day such as "9am", "9:30pm", "14:00", "noon" or
+// "midnight".
+func parseClock(s string) (hour, minute int, ok bool) {
+	switch s {
+	case "noon", "midday":
+		return 12, 0, true
+	case "midnight":
+		return 0, 0, true
+	}
+
+	m := clockPattern.FindStringSubmatch(s)
+	// Require either a meridiem or minutes so bare numbers aren't taken as times.
+	if m == nil || (m[2] == "" && m[3] == "") {
+		return 0, 0, false
+	}
+
+	hour, _ = strconv.Atoi(m[1])
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+
+	switch m[3] {
+	case "am":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 1 || hour > 12 {
+			return 0, 0, false
+		}
+		if hour != 12 {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, false
+	}
+	return hour, minute, true
+}
+
+func atClock(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
@@ -0,0 +1,60 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday 15 May 2024, 15:30 UTC
+	now := time.Date(2024, 5, 15, 15, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "now", want: now},
+		{input: "2024-05-01T09:00:00Z", want: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)},
+		{input: "2024-05-01T09:00:00+02:00", want: time.Date(2024, 5, 1, 7, 0, 0, 0, time.UTC)},
+		{input: "2024-05-01", want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2024-05-01 13:45", want: time.Date(2024, 5, 1, 13, 45, 0, 0, time.UTC)},
+		{input: "1714554000.123456", want: time.Unix(1714554000, 123456000)},
+		{input: "1714554000", want: time.Unix(1714554000, 0)},
+		{input: "2d", want: now.Add(-48 * time.Hour)},
+		{input: "90m", want: now.Add(-90 * time.Minute)},
+		{input: "1w", want: now.Add(-7 * 24 * time.Hour)},
+		{input: "3 days ago", want: now.Add(-72 * time.Hour)},
+		{input: "today", want: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "yesterday", want: time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)},
+		{input: "Yesterday 9am", want: time.Date(2024, 5, 14, 9, 0, 0, 0, time.UTC)},
+		{input: "noon yesterday", want: time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)},
+		{input: "9:30pm", want: time.Date(2024, 5, 15, 21, 30, 0, 0, time.UTC)},
+		{input: "12am", want: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "monday", want: time.Date(2024, 5, 13, 0, 0, 0, 0, time.UTC)},
+		{input: "wednesday", want: time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)},
+		{input: "last wednesday", want: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)},
+		{input: "last monday 14:00", want: time.Date(2024, 5, 13, 14, 0, 0, 0, time.UTC)},
+		{input: "last week", want: time.Date(2024, 5, 8, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("Parse(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnknownInput(t *testing.T) {
+	now := time.Date(2024, 5, 15, 15, 30, 0, 0, time.UTC)
+	for _, input := range []string{"", "soonish", "13pm", "last fortnight", "42"} {
+		if _, err := Parse(input, now); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
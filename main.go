@@ -6,6 +6,7 @@ import (
 	"github.com/alecthomas/kong"
 	"github.com/lox/slack-cli/cmd"
 	"github.com/lox/slack-cli/internal/config"
+	"github.com/lox/slack-cli/internal/output"
 )
 
 var version = "dev"
@@ -22,7 +23,12 @@ func main() {
 	cfg, err := config.Load()
 	ctx.FatalIfErrorf(err)
 
-	err = ctx.Run(&cmd.Context{Config: cfg, Workspace: c.Workspace})
-	ctx.FatalIfErrorf(err)
+	cmdCtx := &cmd.Context{Config: cfg, Workspace: c.Workspace, Output: output.Format(c.Output), NoCache: c.NoCache, Version: version}
+	err = ctx.Run(cmdCtx)
+	cmdCtx.Close()
+	if err != nil {
+		ctx.Errorf("%s", cmd.DescribeError(err))
+		os.Exit(cmd.ExitCode(err))
+	}
 	os.Exit(0)
 }